@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"syscall"
 
 	"github.com/ORBWARRIOR/PolyNav/backend/cmd/server"
+	"github.com/ORBWARRIOR/PolyNav/backend/internal/store"
 	"github.com/rs/zerolog/log"
 )
 
@@ -31,7 +34,31 @@ func setup() (err error) {
 		webAddress = ":50051"
 	}
 
-	grpcServer, err := server.NewServer()
+	// POLYNAV_DEBUG enables debugging RPCs such as ValidateMesh
+	debug := false
+	if v := os.Getenv("POLYNAV_DEBUG"); v != "" {
+		if debug, err = strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("invalid POLYNAV_DEBUG %q: %v", v, err)
+		}
+	}
+
+	// POLYNAV_MAP_DIR is where SaveMap keeps maps. It is resolved once, so
+	// the maps stay put whatever the working directory later becomes.
+	mapDir := os.Getenv("POLYNAV_MAP_DIR")
+	if mapDir == "" {
+		mapDir = "maps"
+	}
+	absDir, err := filepath.Abs(mapDir)
+	if err != nil {
+		return fmt.Errorf("invalid POLYNAV_MAP_DIR %q: %v", mapDir, err)
+	}
+	log.Info().Str("dir", absDir).Msg("Storing maps")
+	maps, err := store.NewFileStore(absDir)
+	if err != nil {
+		return err
+	}
+
+	grpcServer, err := server.NewServer(server.WithDebug(debug), server.WithMapStore(maps))
 	if err != nil {
 		return fmt.Errorf("failed to create gRPC Server, err: %v", err)
 	}
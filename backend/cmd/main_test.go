@@ -2,13 +2,19 @@ package main
 
 import (
 	"context"
+	"io"
+	"math"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/ORBWARRIOR/PolyNav/backend/cmd/server"
+	"github.com/ORBWARRIOR/PolyNav/backend/internal/store"
 	pb "github.com/ORBWARRIOR/PolyNav/backend/pkg/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 const testAddress = ":50052"
@@ -68,6 +74,334 @@ func TestIntegrationTriangulate(t *testing.T) {
 	if len(resp.Triangles) != 2 {
 		t.Errorf("Expected 2 triangles, got %d", len(resp.Triangles))
 	}
+
+	// Opting out leaves only the mesh
+	req.OmitTriangles = true
+	resp, err = client.Triangulate(ctx, req)
+	if err != nil {
+		t.Fatalf("Triangulate RPC failed: %v", err)
+	}
+	if len(resp.Triangles) != 0 || len(resp.Mesh.GetTriangles()) != 3*2 {
+		t.Errorf("Expected only the 2 mesh triangles, got %d listed and %d in the mesh", len(resp.Triangles), len(resp.Mesh.GetTriangles())/3)
+	}
+}
+
+func TestIntegrationIndexedMesh(t *testing.T) {
+	// Start Server
+	srv, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	defer srv.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+
+	square := func(lo, hi float64) *pb.Obstacle {
+		return &pb.Obstacle{Points: []*pb.Point{{X: lo, Y: lo}, {X: hi, Y: lo}, {X: hi, Y: hi}, {X: lo, Y: hi}}}
+	}
+	inputs := append(append([]*pb.Point(nil), square(0, 10).Points...), &pb.Point{X: 5, Y: 5})
+	// Points count in request order: obstacles as sent, including the one
+	// outside the boundary, then the boundary
+	framed := slices.Concat(square(40, 50).Points, square(0, 10).Points, square(-10, 20).Points, []*pb.Point{{X: 15, Y: 15}})
+
+	tests := []struct {
+		name      string
+		obstacles []*pb.Obstacle
+		boundary  *pb.Obstacle
+		refine    *pb.RefineOptions
+		inputs    []*pb.Point
+		dropped   int // Inputs with no vertex
+		vertices  int // 0 when refinement decides
+	}{
+		{name: "Input Vertices Only", obstacles: []*pb.Obstacle{square(0, 10)}, inputs: inputs, vertices: 5},
+		{name: "Refined", obstacles: []*pb.Obstacle{square(0, 10)}, refine: &pb.RefineOptions{MaxArea: 5}, inputs: inputs},
+		{
+			name:      "Obstacle Outside Boundary",
+			obstacles: []*pb.Obstacle{square(40, 50), square(0, 10)},
+			boundary:  square(-10, 20),
+			inputs:    framed,
+			dropped:   4,
+			vertices:  9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			resp, err := client.Triangulate(ctx, &pb.MapData{
+				Obstacles: tt.obstacles,
+				Boundary:  tt.boundary,
+				Start:     tt.inputs[len(tt.inputs)-1],
+				Refine:    tt.refine,
+			})
+			if err != nil {
+				t.Fatalf("Triangulate RPC failed: %v", err)
+			}
+			mesh := resp.Mesh
+			if n := len(resp.Triangles); len(mesh.Triangles) != 3*n || len(mesh.Neighbors) != 3*n || len(mesh.ConstrainedEdges) != 3*n ||
+				len(mesh.Regions) != n || len(mesh.Depths) != n {
+				t.Fatalf("Expected %d triangles in every buffer, got %d, %d, %d, %d and %d",
+					n, len(mesh.Triangles)/3, len(mesh.Neighbors)/3, len(mesh.ConstrainedEdges)/3, len(mesh.Regions), len(mesh.Depths))
+			}
+			if tt.vertices != 0 && len(mesh.Vertices) != tt.vertices {
+				t.Errorf("Expected %d vertices, got %d", tt.vertices, len(mesh.Vertices))
+			}
+
+			// The buffers describe the same triangles as the expanded list.
+			for i, tri := range resp.Triangles {
+				for k, p := range []*pb.Point{tri.A, tri.B, tri.C} {
+					v := mesh.Vertices[mesh.Triangles[3*i+k]]
+					if v.X != p.X || v.Y != p.Y {
+						t.Errorf("Triangle %d vertex %d is %v in the mesh but %v in the list", i, k, v, p)
+					}
+					if mesh.ConstrainedEdges[3*i+k] != tri.ConstrainedEdges[k] {
+						t.Errorf("Triangle %d edge %d constraint flags differ", i, k)
+					}
+				}
+				if mesh.Regions[i] != tri.Region || mesh.Depths[i] != tri.Depth {
+					t.Errorf("Triangle %d region or depth differ", i)
+				}
+			}
+
+			inserted, reported := 0, make(map[int32]bool)
+			for i, idx := range mesh.InputIndices {
+				if idx == -1 {
+					inserted++
+					continue
+				}
+				reported[idx] = true
+				if in, v := tt.inputs[idx], mesh.Vertices[i]; in.X != v.X || in.Y != v.Y {
+					t.Errorf("Vertex %d is %v but input %d is %v", i, v, idx, in)
+				}
+			}
+			if got, want := inserted > 0, tt.refine != nil; got != want {
+				t.Errorf("Expected inserted vertices %v, got %d", want, inserted)
+			}
+			if want := len(tt.inputs) - tt.dropped; len(reported) != want {
+				t.Errorf("Expected %d inputs reported, got %d", want, len(reported))
+			}
+			for idx := range tt.dropped {
+				if reported[int32(idx)] {
+					t.Errorf("Input %d lies outside the boundary but was reported", idx)
+				}
+			}
+		})
+	}
+}
+
+func TestIntegrationDuplicatePoints(t *testing.T) {
+	// Start Server
+	srv, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	defer srv.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+
+	// Each square repeats its second corner; the triangulation merges the
+	// copy, and the square must stay closed through the survivor
+	tests := []struct {
+		name string
+		dup  *pb.Point
+	}{
+		{name: "Exact Duplicate", dup: &pb.Point{X: 10, Y: 0}},
+		{name: "Near Duplicate", dup: &pb.Point{X: 10 + 1e-12, Y: 1e-12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			square := &pb.Obstacle{
+				Points: []*pb.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, tt.dup, {X: 10, Y: 10}, {X: 0, Y: 10}},
+			}
+			resp, err := client.Triangulate(ctx, &pb.MapData{Obstacles: []*pb.Obstacle{square}})
+			if err != nil {
+				t.Fatalf("Triangulate RPC failed: %v", err)
+			}
+
+			if len(resp.Triangles) != 2 {
+				t.Fatalf("Expected 2 triangles, got %d", len(resp.Triangles))
+			}
+			constrained := 0
+			for _, c := range resp.Mesh.ConstrainedEdges {
+				if c {
+					constrained++
+				}
+			}
+			if constrained != 4 {
+				t.Errorf("Expected the 4 sides constrained, got %d", constrained)
+			}
+		})
+	}
+}
+
+func TestIntegrationTriangulateStream(t *testing.T) {
+	// Start Server
+	srv, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	defer srv.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// A 40-gon, so every ring edge is a constraint and every point a vertex
+	circle := &pb.Obstacle{}
+	for i := 0; i < 40; i++ {
+		angle := 2 * math.Pi * float64(i) / 40
+		circle.Points = append(circle.Points, &pb.Point{X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)})
+	}
+	mapData := &pb.MapData{Obstacles: []*pb.Obstacle{circle}}
+
+	stream, err := client.TriangulateStream(ctx, &pb.StreamRequest{Map: mapData, SnapshotEvery: 10})
+	if err != nil {
+		t.Fatalf("TriangulateStream RPC failed: %v", err)
+	}
+	var events []*pb.ProgressEvent
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Stream failed: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	snapshots := 0
+	last := map[pb.Stage]*pb.ProgressEvent{}
+	for i, event := range events {
+		if i > 0 && event.Stage < events[i-1].Stage {
+			t.Errorf("Event %d went back from %v to %v", i, events[i-1].Stage, event.Stage)
+		}
+		if event.Snapshot != nil {
+			snapshots++
+			if event.Stage != pb.Stage_INSERTING || event.Done%10 != 0 {
+				t.Errorf("Unexpected snapshot at %v %d", event.Stage, event.Done)
+			}
+		}
+		last[event.Stage] = event
+	}
+	if snapshots != 4 {
+		t.Errorf("Expected 4 snapshots, got %d", snapshots)
+	}
+	for _, stage := range []pb.Stage{pb.Stage_INSERTING, pb.Stage_CONSTRAINING} {
+		if e := last[stage]; e == nil || e.Done != 40 || e.Total != 40 {
+			t.Errorf("Expected %v to finish at 40 of 40, got %v", stage, e)
+		}
+	}
+	if last[pb.Stage_CLASSIFIED] == nil {
+		t.Error("Expected a CLASSIFIED event")
+	}
+	if last[pb.Stage_STAGE_UNSPECIFIED] != nil {
+		t.Error("Expected every event to carry a stage")
+	}
+	if last[pb.Stage_REFINED] != nil {
+		t.Error("Expected no REFINED event without refine options")
+	}
+
+	done := events[len(events)-1]
+	if done.Stage != pb.Stage_DONE || done.Result == nil {
+		t.Fatalf("Expected the stream to end with a result, got %v", done)
+	}
+	want, err := client.Triangulate(ctx, mapData)
+	if err != nil {
+		t.Fatalf("Triangulate RPC failed: %v", err)
+	}
+	if len(done.Result.Triangles) != len(want.Triangles) {
+		t.Errorf("Streamed result has %d triangles, Triangulate %d", len(done.Result.Triangles), len(want.Triangles))
+	}
+}
+
+func TestIntegrationCancellation(t *testing.T) {
+	// Start Server
+	srv, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	stopped := false
+	defer func() {
+		if !stopped {
+			srv.Shutdown()
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+
+	// A field of small triangles, large enough to take seconds to build
+	big := &pb.MapData{}
+	for i := 0; i < 150; i++ {
+		for j := 0; j < 150; j++ {
+			x, y := float64(i)*3, float64(j)*3
+			big.Obstacles = append(big.Obstacles, &pb.Obstacle{
+				Points: []*pb.Point{{X: x, Y: y}, {X: x + 1, Y: y + 0.2}, {X: x + 0.3, Y: y + 1}},
+			})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Triangulate(ctx, big)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	// GracefulStop waits for running handlers, so it only returns quickly
+	// if the server gave up on the mesh.
+	begin := time.Now()
+	srv.Shutdown()
+	stopped = true
+	if elapsed := time.Since(begin); elapsed > 500*time.Millisecond {
+		t.Errorf("Server kept building for %v after the deadline", elapsed)
+	}
 }
 
 func TestIntegrationEmptyRequest(t *testing.T) {
@@ -105,3 +439,914 @@ func TestIntegrationEmptyRequest(t *testing.T) {
 		t.Errorf("Expected 0 triangles for empty map, got %d", len(resp.Triangles))
 	}
 }
+
+func TestIntegrationTriangulateRefined(t *testing.T) {
+	// Start Server
+	srv, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	defer srv.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+
+	square := &pb.Obstacle{
+		Points: []*pb.Point{
+			{X: 0, Y: 0},
+			{X: 10, Y: 0},
+			{X: 10, Y: 10},
+			{X: 0, Y: 10},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		refine *pb.RefineOptions
+		code   codes.Code
+	}{
+		{name: "Area Bound", refine: &pb.RefineOptions{MinAngle: 25, MaxArea: 5}, code: codes.OK},
+		{name: "Angle Too Large", refine: &pb.RefineOptions{MinAngle: 50}, code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			resp, err := client.Triangulate(ctx, &pb.MapData{Obstacles: []*pb.Obstacle{square}, Refine: tt.refine})
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("Expected code %v, got %v (%v)", tt.code, got, err)
+			}
+			if tt.code != codes.OK {
+				return
+			}
+
+			n := len(resp.Mesh.Triangles) / 3
+			if n <= 2 {
+				t.Fatalf("Expected refinement to add triangles, got %d", n)
+			}
+			for i := range n {
+				area := meshArea(resp.Mesh, i)
+				if area <= 0 || area > tt.refine.MaxArea {
+					t.Errorf("Triangle %d has area %f outside (0, %f]", i, area, tt.refine.MaxArea)
+				}
+			}
+		})
+	}
+}
+
+func TestIntegrationFindPath(t *testing.T) {
+	// Start Server
+	srv, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	defer srv.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+
+	square := func(x0, y0, x1, y1 float64) *pb.Obstacle {
+		return &pb.Obstacle{Points: []*pb.Point{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}}
+	}
+	// Without a boundary the free space ends at the hull of the map's
+	// points; the post below the wall leaves room to pass under it
+	obstacles := []*pb.Obstacle{square(0, 0, 10, 10), square(4, -6, 6, -4)}
+	left, right := &pb.Point{X: -5, Y: 5}, &pb.Point{X: 15, Y: 5}
+
+	tests := []struct {
+		name   string
+		start  *pb.Point
+		goal   *pb.Point
+		smooth bool
+		radius float64
+		status pb.PathStatus
+	}{
+		{name: "Found", start: left, goal: right, status: pb.PathStatus_FOUND},
+		{name: "Smoothed", start: left, goal: right, smooth: true, status: pb.PathStatus_FOUND},
+		{name: "Robot Too Wide", start: left, goal: right, radius: 20, status: pb.PathStatus_START_BLOCKED},
+		{name: "Start In Obstacle", start: &pb.Point{X: 5, Y: 5}, goal: right, status: pb.PathStatus_START_BLOCKED},
+		{name: "Goal In Obstacle", start: left, goal: &pb.Point{X: 5, Y: 5}, status: pb.PathStatus_GOAL_BLOCKED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			in := &pb.MapData{
+				Obstacles: obstacles,
+				Start:     tt.start,
+				Goal:      tt.goal,
+				PathOptions: &pb.PathOptions{
+					Smooth:      tt.smooth,
+					AgentRadius: tt.radius,
+				},
+			}
+			resp, err := client.FindPath(ctx, in)
+			if err != nil {
+				t.Fatalf("FindPath RPC failed: %v", err)
+			}
+			if resp.Status != tt.status {
+				t.Fatalf("Expected status %v, got %v", tt.status, resp.Status)
+			}
+			if tt.status != pb.PathStatus_FOUND {
+				return
+			}
+
+			if len(resp.Waypoints) < 2 || len(resp.Triangles) == 0 {
+				t.Fatalf("Expected waypoints and triangles, got %d and %d", len(resp.Waypoints), len(resp.Triangles))
+			}
+			first, last := resp.Waypoints[0], resp.Waypoints[len(resp.Waypoints)-1]
+			if first.X != tt.start.X || first.Y != tt.start.Y || last.X != tt.goal.X || last.Y != tt.goal.Y {
+				t.Errorf("Path should run from start to goal, got %v -> %v", first, last)
+			}
+			// Raw waypoints are circumcentres, which can lie outside their
+			// triangles, so only the taut path is checked against the walls.
+			// It hugs the wall's lower corners.
+			if tt.smooth {
+				assertAvoidsObstacles(t, resp.Waypoints, obstacles)
+				if want := 10 + 2*math.Hypot(5, 5); math.Abs(resp.Length-want) > 1e-6 {
+					t.Errorf("Expected smoothed length %f, got %f via %v", want, resp.Length, resp.Waypoints)
+				}
+			}
+
+			mesh, err := client.Triangulate(ctx, in)
+			if err != nil {
+				t.Fatalf("Triangulate RPC failed: %v", err)
+			}
+			for _, tIdx := range resp.Triangles {
+				if region := mesh.Mesh.Regions[tIdx]; region != pb.Region_FREE {
+					t.Errorf("Expected the corridor to cross free space, got %v at triangle %d", region, tIdx)
+				}
+			}
+		})
+	}
+}
+
+// assertAvoidsObstacles checks no point along the path lies strictly inside
+// an obstacle, sampling each leg; running along a wall is allowed.
+func assertAvoidsObstacles(t *testing.T, waypoints []*pb.Point, obstacles []*pb.Obstacle) {
+	t.Helper()
+	for i := 1; i < len(waypoints); i++ {
+		a, b := waypoints[i-1], waypoints[i]
+		for k := 0; k <= 100; k++ {
+			f := float64(k) / 100
+			p := &pb.Point{X: a.X + f*(b.X-a.X), Y: a.Y + f*(b.Y-a.Y)}
+			for _, obs := range obstacles {
+				if insideRing(obs.Points, p) && ringDistance(obs.Points, p) > 1e-9 {
+					t.Fatalf("Path leg %v -> %v passes through obstacle %v at %v", a, b, obs.Points, p)
+				}
+			}
+		}
+	}
+}
+
+// ringDistance returns the distance from p to the nearest edge of the ring.
+func ringDistance(ring []*pb.Point, p *pb.Point) float64 {
+	nearest := math.Inf(1)
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%len(ring)]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		f := math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/(dx*dx+dy*dy)))
+		nearest = math.Min(nearest, math.Hypot(a.X+f*dx-p.X, a.Y+f*dy-p.Y))
+	}
+	return nearest
+}
+
+func TestIntegrationNestedObstacles(t *testing.T) {
+	// Start Server
+	srv, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	defer srv.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+
+	ring := func(lo, hi float64, hole bool) *pb.Obstacle {
+		return &pb.Obstacle{
+			Points: []*pb.Point{{X: lo, Y: lo}, {X: hi, Y: lo}, {X: hi, Y: hi}, {X: lo, Y: hi}},
+			Hole:   hole,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		obstacles []*pb.Obstacle
+		start     *pb.Point
+		goal      *pb.Point
+		status    pb.PathStatus
+	}{
+		{
+			name:      "Start In Solid Ring",
+			obstacles: []*pb.Obstacle{ring(0, 10, false), ring(3, 7, false)},
+			start:     &pb.Point{X: 1, Y: 1},
+			goal:      &pb.Point{X: 5, Y: 5},
+			status:    pb.PathStatus_START_BLOCKED,
+		},
+		{
+			name:      "Inside Cavity",
+			obstacles: []*pb.Obstacle{ring(0, 10, false), ring(3, 7, false)},
+			start:     &pb.Point{X: 3.5, Y: 3.5},
+			goal:      &pb.Point{X: 6.5, Y: 6.5},
+			status:    pb.PathStatus_FOUND,
+		},
+		{
+			name:      "Cavity Is Cut Off",
+			obstacles: []*pb.Obstacle{ring(0, 10, false), ring(3, 7, false)},
+			start:     &pb.Point{X: 5, Y: 5},
+			goal:      &pb.Point{X: 12, Y: 12},
+			status:    pb.PathStatus_NO_PATH,
+		},
+		{
+			name:      "Island Stays Solid",
+			obstacles: []*pb.Obstacle{ring(0, 10, false), ring(3, 7, false), ring(4, 6, false)},
+			start:     &pb.Point{X: 3.5, Y: 3.5},
+			goal:      &pb.Point{X: 5, Y: 5},
+			status:    pb.PathStatus_GOAL_BLOCKED,
+		},
+		{
+			name:      "Island Marked As Hole",
+			obstacles: []*pb.Obstacle{ring(0, 10, false), ring(3, 7, false), ring(4, 6, true)},
+			start:     &pb.Point{X: 3.5, Y: 3.5},
+			goal:      &pb.Point{X: 5, Y: 5},
+			status:    pb.PathStatus_NO_PATH,
+		},
+		{
+			name:      "Hole Is Free",
+			obstacles: []*pb.Obstacle{ring(0, 4, true), ring(6, 10, false)},
+			start:     &pb.Point{X: 1, Y: 1},
+			goal:      &pb.Point{X: 3, Y: 3},
+			status:    pb.PathStatus_FOUND,
+		},
+		{
+			name:      "Sibling Of Hole Stays Solid",
+			obstacles: []*pb.Obstacle{ring(0, 4, true), ring(6, 10, false)},
+			start:     &pb.Point{X: 7, Y: 7},
+			goal:      &pb.Point{X: 3, Y: 3},
+			status:    pb.PathStatus_START_BLOCKED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			in := &pb.MapData{Obstacles: tt.obstacles, Start: tt.start, Goal: tt.goal}
+			resp, err := client.FindPath(ctx, in)
+			if err != nil {
+				t.Fatalf("FindPath RPC failed: %v", err)
+			}
+			if resp.Status != tt.status {
+				t.Errorf("Expected status %v, got %v", tt.status, resp.Status)
+			}
+
+			// The whole hull comes back: every solid depth, islands included,
+			// is obstacle interior, and the rest, holes included, is free.
+			// The path only crosses the free triangles.
+			mesh, err := client.Triangulate(ctx, in)
+			if err != nil {
+				t.Fatalf("Triangulate RPC failed: %v", err)
+			}
+			for _, tIdx := range resp.Triangles {
+				if region := mesh.Mesh.Regions[tIdx]; region != pb.Region_FREE {
+					t.Errorf("Expected the corridor to cross free space, got %v at triangle %d", region, tIdx)
+				}
+			}
+			for i, region := range mesh.Mesh.Regions {
+				centroid := &pb.Point{}
+				for k := range 3 {
+					v := mesh.Mesh.Vertices[mesh.Mesh.Triangles[3*i+k]]
+					centroid.X, centroid.Y = centroid.X+v.X/3, centroid.Y+v.Y/3
+				}
+				want := pb.Region_FREE
+				if depth := mesh.Mesh.Depths[i]; depth%2 == 1 && !inHoleRing(tt.obstacles, centroid) {
+					want = pb.Region_OBSTACLE
+				}
+				if region != want {
+					t.Errorf("Expected %v at depth %d, got %v", want, mesh.Mesh.Depths[i], region)
+				}
+			}
+		})
+	}
+}
+
+// meshArea returns the signed area of triangle i of an indexed mesh.
+func meshArea(mesh *pb.IndexedMesh, i int) float64 {
+	a, b, c := mesh.Vertices[mesh.Triangles[3*i]], mesh.Vertices[mesh.Triangles[3*i+1]], mesh.Vertices[mesh.Triangles[3*i+2]]
+	return ((b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)) / 2
+}
+
+// inHoleRing reports whether p lies inside one of the obstacles marked as a
+// hole.
+func inHoleRing(obstacles []*pb.Obstacle, p *pb.Point) bool {
+	for _, obs := range obstacles {
+		if !obs.Hole {
+			continue
+		}
+		if insideRing(obs.Points, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// insideRing reports whether p lies inside the ring (crossing number test).
+func insideRing(ring []*pb.Point, p *pb.Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func TestIntegrationFreeSpace(t *testing.T) {
+	// Start Server
+	srv, err := server.NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	defer srv.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	square := func(lo, hi float64) *pb.Obstacle {
+		return &pb.Obstacle{Points: []*pb.Point{{X: lo, Y: lo}, {X: hi, Y: lo}, {X: hi, Y: hi}, {X: lo, Y: hi}}}
+	}
+	boundary := square(0, 10)
+	// The second obstacle lies outside the boundary and is ignored; the
+	// third is an island inside the first, free again by the even-odd rule
+	obstacles := []*pb.Obstacle{square(3, 7), square(20, 22), square(4, 6)}
+
+	resp, err := client.Triangulate(ctx, &pb.MapData{Obstacles: obstacles, Boundary: boundary})
+	if err != nil {
+		t.Fatalf("Triangulate RPC failed: %v", err)
+	}
+	// Both regions come back in one response, labelled by depth
+	area := map[pb.Region]float64{}
+	for i, region := range resp.Mesh.Regions {
+		want := pb.Region_FREE
+		if resp.Mesh.Depths[i] == 2 {
+			want = pb.Region_OBSTACLE
+		}
+		if region != want {
+			t.Errorf("Expected %v at depth %d, got %v", want, resp.Mesh.Depths[i], region)
+		}
+		area[region] += meshArea(resp.Mesh, i)
+	}
+	if math.Abs(area[pb.Region_FREE]-88) > 1e-9 || math.Abs(area[pb.Region_OBSTACLE]-12) > 1e-9 {
+		t.Errorf("Expected free area 88 and obstacle area 12, got %v", area)
+	}
+
+	tests := []struct {
+		name   string
+		start  *pb.Point
+		goal   *pb.Point
+		status pb.PathStatus
+	}{
+		{name: "Around Obstacle", start: &pb.Point{X: 1, Y: 1}, goal: &pb.Point{X: 9, Y: 9}, status: pb.PathStatus_FOUND},
+		{name: "Start In Obstacle", start: &pb.Point{X: 3.5, Y: 3.5}, goal: &pb.Point{X: 9, Y: 9}, status: pb.PathStatus_START_BLOCKED},
+		{name: "Island Is Cut Off", start: &pb.Point{X: 5, Y: 5}, goal: &pb.Point{X: 9, Y: 9}, status: pb.PathStatus_NO_PATH},
+		{name: "Goal Outside Boundary", start: &pb.Point{X: 1, Y: 1}, goal: &pb.Point{X: 21, Y: 21}, status: pb.PathStatus_GOAL_BLOCKED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.FindPath(ctx, &pb.MapData{
+				Obstacles:   obstacles,
+				Boundary:    boundary,
+				Start:       tt.start,
+				Goal:        tt.goal,
+				PathOptions: &pb.PathOptions{Smooth: true},
+			})
+			if err != nil {
+				t.Fatalf("FindPath RPC failed: %v", err)
+			}
+			if resp.Status != tt.status {
+				t.Fatalf("Expected status %v, got %v", tt.status, resp.Status)
+			}
+			if tt.status != pb.PathStatus_FOUND {
+				return
+			}
+			// The taut path bends round a corner of the obstacle.
+			if resp.Length <= math.Hypot(8, 8)+1e-6 {
+				t.Errorf("Expected path round the obstacle, got length %f", resp.Length)
+			}
+
+			// The corridor indexes Triangulate's triangles, which include the
+			// obstacle's, so every step must land on free space
+			mesh, err := client.Triangulate(ctx, &pb.MapData{Obstacles: obstacles, Boundary: boundary, Start: tt.start, Goal: tt.goal})
+			if err != nil {
+				t.Fatalf("Triangulate RPC failed: %v", err)
+			}
+			for _, tIdx := range resp.Triangles {
+				if region := mesh.Mesh.Regions[tIdx]; region != pb.Region_FREE {
+					t.Errorf("Expected the corridor to cross free space, got %v at triangle %d", region, tIdx)
+				}
+			}
+		})
+	}
+}
+
+func TestIntegrationMapStore(t *testing.T) {
+	maps, err := store.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to open map store: %v", err)
+	}
+
+	// Start Server
+	srv, err := server.NewServer(server.WithMapStore(maps))
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	defer srv.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Create Client
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGeometryServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	mapData := &pb.MapData{
+		Name: "square",
+		Obstacles: []*pb.Obstacle{
+			{Points: []*pb.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}},
+		},
+		Start: &pb.Point{X: 2, Y: 2},
+		Goal:  &pb.Point{X: 8, Y: 7},
+	}
+	saved, err := client.SaveMap(ctx, mapData)
+	if err != nil {
+		t.Fatalf("SaveMap RPC failed: %v", err)
+	}
+	if !saved.Success || saved.MapId == "" {
+		t.Fatalf("Expected a saved map with an ID, got %v", saved)
+	}
+
+	loaded, err := client.LoadMap(ctx, &pb.MapRequest{MapId: saved.MapId})
+	if err != nil {
+		t.Fatalf("LoadMap RPC failed: %v", err)
+	}
+	if loaded.Name != "square" || loaded.Map.MapId != saved.MapId || len(loaded.Map.Obstacles) != 1 || loaded.Map.Goal.GetX() != 8 {
+		t.Errorf("Loaded map does not match the saved one: %v", loaded)
+	}
+	want, err := client.Triangulate(ctx, mapData)
+	if err != nil {
+		t.Fatalf("Triangulate RPC failed: %v", err)
+	}
+	if len(loaded.Mesh.GetTriangles()) != len(want.Triangles) {
+		t.Errorf("Expected a cached mesh of %d triangles, got %d", len(want.Triangles), len(loaded.Mesh.GetTriangles()))
+	}
+
+	// Saving a loaded map overwrites it
+	loaded.Map.Name = "renamed"
+	resaved, err := client.SaveMap(ctx, loaded.Map)
+	if err != nil {
+		t.Fatalf("SaveMap RPC failed: %v", err)
+	}
+	if resaved.MapId != saved.MapId {
+		t.Errorf("Expected map %s to be overwritten, got %s", saved.MapId, resaved.MapId)
+	}
+
+	list, err := client.ListMaps(ctx, &pb.ListMapsRequest{})
+	if err != nil {
+		t.Fatalf("ListMaps RPC failed: %v", err)
+	}
+	if len(list.Maps) != 1 || list.Maps[0].Name != "renamed" || list.Maps[0].ObstacleCount != 1 {
+		t.Errorf("Expected one renamed map, got %v", list.Maps)
+	}
+
+	if _, err := client.DeleteMap(ctx, &pb.MapRequest{MapId: saved.MapId}); err != nil {
+		t.Fatalf("DeleteMap RPC failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "Load Deleted", call: func() error { _, err := client.LoadMap(ctx, &pb.MapRequest{MapId: saved.MapId}); return err }},
+		{name: "Delete Deleted", call: func() error { _, err := client.DeleteMap(ctx, &pb.MapRequest{MapId: saved.MapId}); return err }},
+		{name: "Overwrite Deleted", call: func() error { _, err := client.SaveMap(ctx, loaded.Map); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != codes.NotFound {
+				t.Errorf("Expected NotFound, got %v", err)
+			}
+		})
+	}
+}
+
+// startSessionServer starts a server with opts and returns a client for it.
+// Both are closed when the test ends.
+func startSessionServer(t *testing.T, opts ...server.Option) pb.GeometryServiceClient {
+	t.Helper()
+	srv, err := server.NewServer(opts...)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	errorC := make(chan error, 1)
+	go srv.Run(testAddress, errorC)
+	t.Cleanup(srv.Shutdown)
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewGeometryServiceClient(conn)
+}
+
+func TestIntegrationSessions(t *testing.T) {
+	ttl := time.Second
+	client := startSessionServer(t, server.WithSessionTTL(ttl))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	square := func(x, y, size float64) *pb.Obstacle {
+		return &pb.Obstacle{Points: []*pb.Point{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}}
+	}
+	mapData := &pb.MapData{
+		Obstacles: []*pb.Obstacle{square(0, 0, 10)},
+		Start:     &pb.Point{X: -5, Y: 15},
+		Goal:      &pb.Point{X: 40, Y: -5},
+	}
+
+	// After each edit the session's mesh must match a fresh triangulation
+	// of the edited map
+	assertMatches := func(t *testing.T, sessionID string, want *pb.MapData) {
+		t.Helper()
+		got, err := client.GetMesh(ctx, &pb.SessionRequest{SessionId: sessionID})
+		if err != nil {
+			t.Fatalf("GetMesh RPC failed: %v", err)
+		}
+		fresh, err := client.Triangulate(ctx, want)
+		if err != nil {
+			t.Fatalf("Triangulate RPC failed: %v", err)
+		}
+		if len(got.Triangles) != len(fresh.Triangles) {
+			t.Errorf("Expected %d triangles, got %d", len(fresh.Triangles), len(got.Triangles))
+		}
+		if len(got.Mesh.GetInputIndices()) != len(fresh.Mesh.GetInputIndices()) {
+			t.Errorf("Expected %d vertices, got %d", len(fresh.Mesh.GetInputIndices()), len(got.Mesh.GetInputIndices()))
+		}
+		// Input indices count the edited map's points, as Triangulate does
+		inputs := make(map[int32]*pb.Point)
+		for i, idx := range fresh.Mesh.GetInputIndices() {
+			inputs[idx] = fresh.Mesh.Vertices[i]
+		}
+		for i, idx := range got.Mesh.GetInputIndices() {
+			if want, v := inputs[idx], got.Mesh.Vertices[i]; idx != -1 && (want == nil || want.X != v.X || want.Y != v.Y) {
+				t.Errorf("Vertex %d at %v reports input %d, which Triangulate puts at %v", i, v, idx, want)
+			}
+		}
+	}
+
+	info, err := client.CreateSession(ctx, mapData)
+	if err != nil {
+		t.Fatalf("CreateSession RPC failed: %v", err)
+	}
+	if info.SessionId == "" || len(info.ObstacleIds) != 1 {
+		t.Fatalf("Expected a session with one obstacle, got %v", info)
+	}
+	id := info.SessionId
+	assertMatches(t, id, mapData)
+
+	added, err := client.AddObstacle(ctx, &pb.AddObstacleRequest{SessionId: id, Obstacle: square(20, 0, 10)})
+	if err != nil {
+		t.Fatalf("AddObstacle RPC failed: %v", err)
+	}
+	mapData.Obstacles = append(mapData.Obstacles, square(20, 0, 10))
+	assertMatches(t, id, mapData)
+
+	_, err = client.MovePoint(ctx, &pb.MovePointRequest{
+		SessionId: id, ObstacleId: added.ObstacleId, PointIndex: 2, To: &pb.Point{X: 35, Y: 15},
+	})
+	if err != nil {
+		t.Fatalf("MovePoint RPC failed: %v", err)
+	}
+	mapData.Obstacles[1].Points[2] = &pb.Point{X: 35, Y: 15}
+	assertMatches(t, id, mapData)
+
+	if _, err := client.RemoveObstacle(ctx, &pb.ObstacleRef{SessionId: id, ObstacleId: info.ObstacleIds[0]}); err != nil {
+		t.Fatalf("RemoveObstacle RPC failed: %v", err)
+	}
+	mapData.Obstacles = mapData.Obstacles[1:]
+	assertMatches(t, id, mapData)
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{
+			name: "Unknown Session",
+			call: func() error { _, err := client.GetMesh(ctx, &pb.SessionRequest{SessionId: "missing"}); return err },
+			code: codes.NotFound,
+		},
+		{
+			name: "Removed Obstacle",
+			call: func() error {
+				_, err := client.RemoveObstacle(ctx, &pb.ObstacleRef{SessionId: id, ObstacleId: info.ObstacleIds[0]})
+				return err
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "Point Out Of Range",
+			call: func() error {
+				_, err := client.MovePoint(ctx, &pb.MovePointRequest{
+					SessionId: id, ObstacleId: added.ObstacleId, PointIndex: 4, To: &pb.Point{X: 1, Y: 1},
+				})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Degenerate Obstacle",
+			call: func() error {
+				_, err := client.AddObstacle(ctx, &pb.AddObstacleRequest{
+					SessionId: id, Obstacle: &pb.Obstacle{Points: []*pb.Point{{X: 1, Y: 1}, {X: 2, Y: 2}}},
+				})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.code {
+				t.Errorf("Expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	// Closing ends the session at once; idling ends it after the TTL
+	if _, err := client.CloseSession(ctx, &pb.SessionRequest{SessionId: id}); err != nil {
+		t.Fatalf("CloseSession RPC failed: %v", err)
+	}
+	if _, err := client.GetMesh(ctx, &pb.SessionRequest{SessionId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound after CloseSession, got %v", err)
+	}
+	idle, err := client.CreateSession(ctx, mapData)
+	if err != nil {
+		t.Fatalf("CreateSession RPC failed: %v", err)
+	}
+	time.Sleep(ttl + 500*time.Millisecond)
+	if _, err := client.GetMesh(ctx, &pb.SessionRequest{SessionId: idle.SessionId}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound after the session expired, got %v", err)
+	}
+}
+
+func TestIntegrationSessionLimit(t *testing.T) {
+	if _, err := server.NewServer(server.WithMaxSessions(0)); err == nil {
+		t.Error("Expected an error for a session limit of 0")
+	}
+
+	client := startSessionServer(t, server.WithMaxSessions(2))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	mapData := &pb.MapData{Obstacles: []*pb.Obstacle{{Points: []*pb.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 10}}}}}
+	var ids []string
+	for range 2 {
+		info, err := client.CreateSession(ctx, mapData)
+		if err != nil {
+			t.Fatalf("CreateSession RPC failed: %v", err)
+		}
+		ids = append(ids, info.SessionId)
+	}
+	if _, err := client.CreateSession(ctx, mapData); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted at the limit, got %v", err)
+	}
+
+	// Closing a session makes room for another
+	if _, err := client.CloseSession(ctx, &pb.SessionRequest{SessionId: ids[0]}); err != nil {
+		t.Fatalf("CloseSession RPC failed: %v", err)
+	}
+	if _, err := client.CreateSession(ctx, mapData); err != nil {
+		t.Errorf("Expected a session once one was closed, got %v", err)
+	}
+}
+
+func TestIntegrationSessionTouchingObstacles(t *testing.T) {
+	client := startSessionServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Two slanted bars crossing away from any of their corners, so the
+	// session inserts a vertex at each crossing
+	crossing := func() []*pb.Obstacle {
+		return []*pb.Obstacle{
+			{Points: []*pb.Point{{X: 0, Y: 0.1}, {X: 10, Y: 3.3}, {X: 10, Y: 4.3}, {X: 0, Y: 1.1}}},
+			{Points: []*pb.Point{{X: 0.3, Y: 3.1}, {X: 9.7, Y: 0.2}, {X: 9.7, Y: 1.2}, {X: 0.3, Y: 4.1}}},
+		}
+	}
+	// Two squares sharing part of the wall x=10
+	sharing := func() []*pb.Obstacle {
+		return []*pb.Obstacle{
+			{Points: []*pb.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}},
+			{Points: []*pb.Point{{X: 10, Y: 5}, {X: 20, Y: 5}, {X: 20, Y: 15}, {X: 10, Y: 15}}},
+		}
+	}
+	area := func(res *pb.TriangulationResult) float64 {
+		total := 0.0
+		for i, region := range res.Mesh.GetRegions() {
+			if region == pb.Region_OBSTACLE {
+				total += meshArea(res.Mesh, i)
+			}
+		}
+		return total
+	}
+
+	tests := []struct {
+		name   string
+		layout func() []*pb.Obstacle
+		edited int
+		move   bool
+	}{
+		{name: "Remove First Crossing", layout: crossing, edited: 0},
+		{name: "Remove Second Crossing", layout: crossing, edited: 1},
+		{name: "Move First Crossing", layout: crossing, edited: 0, move: true},
+		{name: "Move Second Crossing", layout: crossing, edited: 1, move: true},
+		{name: "Remove First Sharing", layout: sharing, edited: 0},
+		{name: "Remove Second Sharing", layout: sharing, edited: 1},
+		{name: "Move First Sharing", layout: sharing, edited: 0, move: true},
+		{name: "Move Second Sharing", layout: sharing, edited: 1, move: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// One obstacle comes with the session, the other is added to it
+			obstacles := tt.layout()
+			info, err := client.CreateSession(ctx, &pb.MapData{Obstacles: obstacles[:1]})
+			if err != nil {
+				t.Fatalf("CreateSession RPC failed: %v", err)
+			}
+			added, err := client.AddObstacle(ctx, &pb.AddObstacleRequest{SessionId: info.SessionId, Obstacle: obstacles[1]})
+			if err != nil {
+				t.Fatalf("AddObstacle RPC failed: %v", err)
+			}
+			ref := &pb.ObstacleRef{SessionId: info.SessionId, ObstacleId: []int32{info.ObstacleIds[0], added.ObstacleId}[tt.edited]}
+
+			if tt.move {
+				// Stretch the corner opposite the first point outwards
+				to := obstacles[tt.edited].Points[2]
+				to = &pb.Point{X: to.X + 1, Y: to.Y + 2}
+				_, err = client.MovePoint(ctx, &pb.MovePointRequest{
+					SessionId: ref.SessionId, ObstacleId: ref.ObstacleId, PointIndex: 2, To: to,
+				})
+				obstacles[tt.edited].Points[2] = to
+			} else {
+				_, err = client.RemoveObstacle(ctx, ref)
+				obstacles = append(obstacles[:tt.edited], obstacles[tt.edited+1:]...)
+			}
+			if err != nil {
+				t.Fatalf("Edit failed: %v", err)
+			}
+
+			// Crossing vertices stay behind, so compare the area covered
+			got, err := client.GetMesh(ctx, &pb.SessionRequest{SessionId: info.SessionId})
+			if err != nil {
+				t.Fatalf("GetMesh RPC failed: %v", err)
+			}
+			fresh, err := client.Triangulate(ctx, &pb.MapData{Obstacles: obstacles})
+			if err != nil {
+				t.Fatalf("Triangulate RPC failed: %v", err)
+			}
+			if math.Abs(area(got)-area(fresh)) > 1e-6 {
+				t.Errorf("Expected obstacle area %f, got %f", area(fresh), area(got))
+			}
+		})
+	}
+}
+
+func TestIntegrationValidateMesh(t *testing.T) {
+	square := &pb.Obstacle{
+		Points: []*pb.Point{
+			{X: 0, Y: 0},
+			{X: 10, Y: 0},
+			{X: 10, Y: 10},
+			{X: 0, Y: 10},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		debug  bool
+		refine *pb.RefineOptions
+		code   codes.Code
+	}{
+		{name: "Debug Mode", debug: true, code: codes.OK},
+		{name: "Refined", debug: true, refine: &pb.RefineOptions{MaxArea: 5}, code: codes.OK},
+		{name: "Release Mode", debug: false, code: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Start Server
+			srv, err := server.NewServer(server.WithDebug(tt.debug))
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+			errorC := make(chan error, 1)
+			go srv.Run(testAddress, errorC)
+			defer srv.Shutdown()
+			time.Sleep(100 * time.Millisecond)
+
+			// Create Client
+			conn, err := grpc.NewClient("localhost"+testAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				t.Fatalf("Failed to dial server: %v", err)
+			}
+			defer conn.Close()
+
+			client := pb.NewGeometryServiceClient(conn)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			in := &pb.MapData{
+				Obstacles: []*pb.Obstacle{square},
+				Start:     &pb.Point{X: 3, Y: 4},
+				Refine:    tt.refine,
+			}
+			resp, err := client.ValidateMesh(ctx, in)
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("Expected code %v, got %v (%v)", tt.code, got, err)
+			}
+			if tt.code != codes.OK {
+				return
+			}
+
+			if !resp.Valid || len(resp.Violations) != 0 {
+				t.Errorf("Expected a valid mesh, got %v", resp.Violations)
+			}
+
+			tri, err := client.Triangulate(ctx, in)
+			if err != nil {
+				t.Fatalf("Triangulate failed: %v", err)
+			}
+			if tt.refine == nil && len(tri.Triangles) != 4 {
+				t.Errorf("Expected 4 triangles, got %d", len(tri.Triangles))
+			}
+			if int(resp.TriangleCount) != len(tri.Triangles) {
+				t.Errorf("Expected %d triangles, got %d", len(tri.Triangles), resp.TriangleCount)
+			}
+		})
+	}
+}
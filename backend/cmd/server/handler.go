@@ -2,23 +2,331 @@ package server
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/ORBWARRIOR/PolyNav/backend/internal/algo"
+	"github.com/ORBWARRIOR/PolyNav/backend/internal/store"
 	pb "github.com/ORBWARRIOR/PolyNav/backend/pkg/proto"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type server struct {
 	pb.UnimplementedGeometryServiceServer
+	debug bool
+	maps  store.Repository // nil disables the map RPCs
+
+	sessions    *sessionManager
+	sessionTTL  time.Duration
+	maxSessions int
 }
 
 func (s *server) Triangulate(ctx context.Context, in *pb.MapData) (*pb.TriangulationResult, error) {
 	log.Info().Int("obstacles", len(in.Obstacles)).Msg("Received Triangulate request")
 
+	dt, err := buildMesh(ctx, in, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dt == nil {
+		return &pb.TriangulationResult{}, nil
+	}
+	return triangulationResult(dt, in, inputVertices(dt, in)), nil
+}
+
+// progressSteps caps the events TriangulateStream sends per stage, not
+// counting snapshots, so large maps do not send one event per point.
+const progressSteps = 100
+
+func (s *server) TriangulateStream(req *pb.StreamRequest, stream grpc.ServerStreamingServer[pb.ProgressEvent]) error {
+	in := req.GetMap()
+	if in == nil {
+		in = &pb.MapData{}
+	}
+	log.Info().Int("obstacles", len(in.Obstacles)).Msg("Received TriangulateStream request")
+
+	// A failed send ends the stream; the mesh is still built, but nothing
+	// more is sent.
+	var sendErr error
+	send := func(event *pb.ProgressEvent) {
+		if sendErr == nil {
+			sendErr = stream.Send(event)
+		}
+	}
+
+	every := int(req.GetSnapshotEvery())
+	dt, err := buildMesh(stream.Context(), in, func(stage pb.Stage, done, total int, dt *algo.Delaunay) {
+		event := &pb.ProgressEvent{Stage: stage, Done: int32(done), Total: int32(total)}
+		if stage == pb.Stage_INSERTING && every > 0 && done%every == 0 {
+			event.Snapshot = indexedMesh(dt, inputVertices(dt, in))
+		} else if done != total && done%max(total/progressSteps, 1) != 0 {
+			return
+		}
+		send(event)
+	})
+	if err != nil {
+		return err
+	}
+
+	result := &pb.TriangulationResult{}
+	if dt != nil {
+		result = triangulationResult(dt, in, inputVertices(dt, in))
+	}
+	send(&pb.ProgressEvent{Stage: pb.Stage_DONE, Result: result})
+	return sendErr
+}
+
+// triangulationResult converts a mesh from buildMesh to the Triangulate
+// response, with inputs as for indexedMesh. The triangles buildMesh marked
+// Inside are the obstacle interiors, or the free space when the map has a
+// boundary; the rest are the other region. The triangle list is left out
+// when the map asks for only the mesh.
+func triangulationResult(dt *algo.Delaunay, in *pb.MapData, inputs []int) *pb.TriangulationResult {
+	result := &pb.TriangulationResult{Mesh: indexedMesh(dt, inputs)}
+	for _, t := range dt.Triangles {
+		if !t.Active {
+			continue
+		}
+		region := region(t, in)
+		result.Mesh.Regions = append(result.Mesh.Regions, region)
+		result.Mesh.Depths = append(result.Mesh.Depths, t.Depth)
+		if in.GetOmitTriangles() {
+			continue
+		}
+
+		p1 := dt.Points[t.A]
+		p2 := dt.Points[t.B]
+		p3 := dt.Points[t.C]
+
+		result.Triangles = append(result.Triangles, &pb.Triangle{
+			A:                &pb.Point{X: p1.X, Y: p1.Y},
+			B:                &pb.Point{X: p2.X, Y: p2.Y},
+			C:                &pb.Point{X: p3.X, Y: p3.Y},
+			ConstrainedEdges: []bool{t.Constrained[0], t.Constrained[1], t.Constrained[2]},
+			Region:           region,
+			Depth:            t.Depth,
+		})
+	}
+	return result
+}
+
+// region returns what a triangle of a mesh from buildMesh covers.
+func region(t algo.Triangle, in *pb.MapData) pb.Region {
+	if t.Inside == hasBoundary(in) {
+		return pb.Region_FREE
+	}
+	return pb.Region_OBSTACLE
+}
+
+// indexedMesh converts the mesh to shared buffers. inputs holds the vertex
+// of each request point, in the order input_indices counts them; a vertex
+// reports the first point mapped to it.
+func indexedMesh(dt *algo.Delaunay, inputs []int) *pb.IndexedMesh {
+	first := make(map[int]int32, len(inputs))
+	for i, v := range inputs {
+		if _, seen := first[v]; !seen && v != -1 {
+			first[v] = int32(i)
+		}
+	}
+
+	mesh := dt.ExportMesh()
+	result := &pb.IndexedMesh{
+		Triangles:        mesh.Triangles,
+		Neighbors:        mesh.Neighbors,
+		ConstrainedEdges: mesh.Constrained,
+	}
+	for i, v := range mesh.Vertices {
+		result.Vertices = append(result.Vertices, &pb.Point{X: v.X, Y: v.Y})
+		idx, ok := first[mesh.Sources[i]]
+		if !ok {
+			idx = -1
+		}
+		result.InputIndices = append(result.InputIndices, idx)
+	}
+	return result
+}
+
+// inputVertices returns the vertex of each of the request's points, in the
+// order input_indices counts them: the obstacles as sent, then the boundary,
+// start and goal. constrainMesh passes NewDelaunay mapRings' points instead,
+// boundary first and without the obstacles outside it, whose points map
+// to -1 here.
+func inputVertices(dt *algo.Delaunay, in *pb.MapData) []int {
+	// Position of each ring's first point among those given to NewDelaunay
+	offset := make(map[*pb.Obstacle]int)
+	n := 0
+	for _, obs := range mapRings(in) {
+		offset[obs] = n
+		n += len(obs.Points)
+	}
+
+	var inputs []int
+	add := func(obs *pb.Obstacle) {
+		first, ok := offset[obs]
+		for k := range obs.GetPoints() {
+			v := -1
+			if ok {
+				v = dt.InputVertex(first + k)
+			}
+			inputs = append(inputs, v)
+		}
+	}
+	for _, obs := range in.Obstacles {
+		add(obs)
+	}
+	add(in.Boundary)
+	for _, p := range []*pb.Point{in.Start, in.Goal} {
+		if p != nil {
+			inputs = append(inputs, dt.InputVertex(n))
+			n++
+		}
+	}
+	return inputs
+}
+
+func (s *server) FindPath(ctx context.Context, in *pb.MapData) (*pb.PathResult, error) {
+	log.Info().Int("obstacles", len(in.Obstacles)).Msg("Received FindPath request")
+
+	if in.Start == nil {
+		return &pb.PathResult{Status: pb.PathStatus_START_BLOCKED}, nil
+	}
+	if in.Goal == nil {
+		return &pb.PathResult{Status: pb.PathStatus_GOAL_BLOCKED}, nil
+	}
+
+	dt, err := buildMesh(ctx, in, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dt == nil {
+		return &pb.PathResult{Status: pb.PathStatus_START_BLOCKED}, nil
+	}
+
+	// The path runs through the carved mesh, whose triangles are those
+	// Triangulate labels FREE, in the same order.
+	kept := keptTriangles(dt, in)
+	if err := carveMesh(ctx, dt, in); err != nil {
+		return nil, err
+	}
+
+	start := algo.Point{X: in.Start.X, Y: in.Start.Y}
+	goal := algo.Point{X: in.Goal.X, Y: in.Goal.Y}
+	path, err := dt.FindPathWithRadius(start, goal, in.PathOptions.GetAgentRadius())
+	switch {
+	case errors.Is(err, algo.ErrStartBlocked):
+		return &pb.PathResult{Status: pb.PathStatus_START_BLOCKED}, nil
+	case errors.Is(err, algo.ErrGoalBlocked):
+		return &pb.PathResult{Status: pb.PathStatus_GOAL_BLOCKED}, nil
+	case errors.Is(err, algo.ErrNoPath):
+		return &pb.PathResult{Status: pb.PathStatus_NO_PATH}, nil
+	case err != nil:
+		log.Err(err).Msg("Path search failed")
+		return nil, err
+	}
+
+	if in.PathOptions.GetSmooth() {
+		if path, err = dt.SmoothPath(path); err != nil {
+			log.Err(err).Msg("Path smoothing failed")
+			return nil, err
+		}
+	}
+
+	result := &pb.PathResult{Status: pb.PathStatus_FOUND, Length: path.Cost}
+	for _, p := range path.Waypoints {
+		result.Waypoints = append(result.Waypoints, &pb.Point{X: p.X, Y: p.Y})
+	}
+	for _, tIdx := range path.Triangles {
+		result.Triangles = append(result.Triangles, kept[tIdx])
+	}
+	return result, nil
+}
+
+// keptTriangles returns, for each triangle carveMesh will keep, its position
+// among the active triangles, which is its index in triangulationResult.
+func keptTriangles(dt *algo.Delaunay, in *pb.MapData) []int32 {
+	var kept []int32
+	pos := int32(0)
+	for _, t := range dt.Triangles {
+		if !t.Active {
+			continue
+		}
+		if region(t, in) == pb.Region_FREE {
+			kept = append(kept, pos)
+		}
+		pos++
+	}
+	return kept
+}
+
+func (s *server) ValidateMesh(ctx context.Context, in *pb.MapData) (*pb.ValidationResult, error) {
+	log.Info().Int("obstacles", len(in.Obstacles)).Msg("Received ValidateMesh request")
+
+	if !s.debug {
+		return nil, status.Error(codes.FailedPrecondition, "ValidateMesh is only available in debug mode")
+	}
+
+	dt, err := buildMesh(ctx, in, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dt == nil {
+		return &pb.ValidationResult{Valid: true}, nil
+	}
+
+	violations := dt.Validate()
+	result := &pb.ValidationResult{Valid: len(violations) == 0, TriangleCount: int32(dt.TriangleCount())}
+	for _, v := range violations {
+		log.Warn().Stringer("kind", v.Kind).Msg(v.Message)
+		result.Violations = append(result.Violations, &pb.Violation{
+			Kind:     v.Kind.String(),
+			Triangle: int32(v.Triangle),
+			Neighbor: int32(v.Neighbor),
+			Message:  v.Message,
+		})
+	}
+	return result, nil
+}
+
+// meshProgress receives buildMesh's progress through a stage, with the mesh
+// as it stands.
+type meshProgress func(stage pb.Stage, done, total int, dt *algo.Delaunay)
+
+// buildMesh runs the constrained triangulation for a map, marks the
+// triangles the even-odd rule keeps (minus any rings marked as holes) as
+// Inside and refines it if requested. With a boundary, the boundary is the
+// outermost ring, so the odd depths kept are the free space around the
+// obstacles. Both regions stay in the mesh; carveMesh drops the obstacles.
+// It returns a nil mesh when there are too few points. A non-nil progress is
+// called as each stage advances. Once ctx is done it stops with the
+// matching gRPC status.
+func buildMesh(ctx context.Context, in *pb.MapData, progress meshProgress) (*algo.Delaunay, error) {
+	if progress == nil {
+		progress = func(pb.Stage, int, int, *algo.Delaunay) {}
+	}
+
+	rings := mapRings(in)
+	dt, _, err := constrainMesh(ctx, in, rings, progress)
+	if dt == nil || err != nil {
+		return nil, err
+	}
+	if err := classifyMesh(ctx, dt, in, rings, progress); err != nil {
+		return nil, err
+	}
+	return dt, nil
+}
+
+// constrainMesh is the first half of buildMesh: it triangulates the map's
+// points and constrains the rings, returning each ring as vertex indices.
+// It returns a nil mesh when there are too few points.
+func constrainMesh(ctx context.Context, in *pb.MapData, rings []*pb.Obstacle, progress meshProgress) (*algo.Delaunay, [][]int, error) {
 	var allPoints []algo.Point
 
 	// Collect all points for triangulation
-	for _, obs := range in.Obstacles {
+	for _, obs := range rings {
 		for _, p := range obs.Points {
 			allPoints = append(allPoints, algo.Point{X: p.X, Y: p.Y})
 		}
@@ -31,74 +339,291 @@ func (s *server) Triangulate(ctx context.Context, in *pb.MapData) (*pb.Triangula
 	}
 
 	if len(allPoints) < 3 {
-		return &pb.TriangulationResult{}, nil
+		return nil, nil, nil
+	}
+	if dropped := len(in.Obstacles) + 1 - len(rings); hasBoundary(in) && dropped > 0 {
+		log.Warn().Int("obstacles", dropped).Msg("Ignoring obstacles outside the boundary")
 	}
 
 	dt, err := algo.NewDelaunay(allPoints)
 	if err != nil {
 		log.Err(err).Msg("Delaunay initialisation failed")
-		return nil, err
+		return nil, nil, err
+	}
+	err = dt.TriangulateFuncContext(ctx, func(inserted, total int) {
+		progress(pb.Stage_INSERTING, inserted, total, dt)
+	})
+	if err != nil {
+		return nil, nil, contextStatus(err)
+	}
+
+	segments, applied := 0, 0
+	for _, obs := range rings {
+		if len(obs.Points) >= 3 {
+			segments += len(obs.Points)
+		}
+	}
+
+	// Add Constraints for each obstacle (assuming they are closed loops).
+	// The ring points lead allPoints in order, so input k is the k-th of
+	// them; a point merged as a duplicate shares its survivor's vertex.
+	ringVerts := make([][]int, len(rings))
+	input := 0
+	for i, obs := range rings {
+		for range obs.Points {
+			ringVerts[i] = append(ringVerts[i], dt.InputVertex(input))
+			input++
+		}
+		err := constrainRing(ctx, dt, ringVerts[i], func() {
+			applied++
+			progress(pb.Stage_CONSTRAINING, applied, segments, dt)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
 	}
-	dt.Triangulate()
+	return dt, ringVerts, nil
+}
 
-	// Optimization: Create a lookup map for O(1) access
-	// We rely on the fact that input points are copied exactly.
-	pointMap := make(map[algo.Point]int, len(dt.Points))
-	for i, p := range dt.Points {
-		pointMap[p] = i
+// constrainRing constrains the edges of a closed ring of vertex indices,
+// calling onEdge after each. Edges with a missing endpoint (-1) or that fail
+// to insert are skipped, so only ctx ending stops it.
+func constrainRing(ctx context.Context, dt *algo.Delaunay, ring []int, onEdge func()) error {
+	if len(ring) < 3 {
+		return nil
 	}
+	for i := range ring {
+		idx1, idx2 := ring[i], ring[(i+1)%len(ring)]
+		if idx1 != -1 && idx2 != -1 {
+			err := dt.AddConstraintContext(ctx, idx1, idx2)
+			if ctx.Err() != nil {
+				return contextStatus(ctx.Err())
+			}
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to add constraint")
+			}
+		}
+		if onEdge != nil {
+			onEdge()
+		}
+	}
+	return nil
+}
 
-	// Helper to find index in dt.Points using the map
-	getIdx := func(p *pb.Point) int {
-		pt := algo.Point{X: p.X, Y: p.Y}
-		if idx, exists := pointMap[pt]; exists {
-			return idx
+// classifyMesh is the second half of buildMesh: it sets each triangle's
+// depth, marks the odd depths Inside except directly inside rings marked as
+// holes, and refines the mesh if the map asks for it. New triangles inherit
+// Inside from the ones they replace.
+func classifyMesh(ctx context.Context, dt *algo.Delaunay, in *pb.MapData, rings []*pb.Obstacle, progress meshProgress) error {
+	holes := holeRings(rings)
+	if err := dt.ComputeDepthsContext(ctx); err != nil {
+		return contextStatus(err)
+	}
+	kept := 0
+	for i, t := range dt.Triangles {
+		dt.Triangles[i].Inside = t.Active && evenOddKeeps(dt, t, holes)
+		if dt.Triangles[i].Inside {
+			kept++
+		}
+	}
+	progress(pb.Stage_CLASSIFIED, kept, kept, dt)
+
+	if opts := in.GetRefine(); opts != nil {
+		added, err := dt.RefineContext(ctx, algo.RefineOptions{
+			MinAngle:   opts.MinAngle,
+			MaxArea:    opts.MaxArea,
+			MaxSteiner: int(opts.MaxSteiner),
+		})
+		switch {
+		case errors.Is(err, algo.ErrRefineLimit):
+			log.Warn().Int("steiner", added).Msg("Refinement stopped at the Steiner point limit")
+		case ctx.Err() != nil:
+			return contextStatus(err)
+		case err != nil:
+			return status.Error(codes.InvalidArgument, err.Error())
 		}
-		return -1
+		progress(pb.Stage_REFINED, added, added, dt)
 	}
+	return nil
+}
 
-	// Add Constraints for each obstacle (assuming they are closed loops)
+// carveMesh removes the obstacle region, leaving the free space paths are
+// planned through. Without a boundary that is the convex hull of the map's
+// points outside the obstacles.
+func carveMesh(ctx context.Context, dt *algo.Delaunay, in *pb.MapData) error {
+	err := dt.ClassifyRegionsFuncContext(ctx, func(t algo.Triangle) bool { return region(t, in) == pb.Region_FREE })
+	if err != nil {
+		return contextStatus(err)
+	}
+	return nil
+}
+
+// contextStatus converts a context error to the gRPC status a client sees
+// for it: Canceled or DeadlineExceeded.
+func contextStatus(err error) error {
+	log.Warn().Err(err).Msg("Mesh construction abandoned")
+	return status.FromContextError(err).Err()
+}
+
+// hasBoundary reports whether the map asks for a free-space mesh.
+func hasBoundary(in *pb.MapData) bool {
+	return len(in.GetBoundary().GetPoints()) >= 3
+}
+
+// mapRings returns the rings to constrain: the obstacles, preceded by the
+// boundary if there is one. Obstacles outside the boundary are dropped, as
+// the even-odd rule would otherwise count them as free space.
+func mapRings(in *pb.MapData) []*pb.Obstacle {
+	if !hasBoundary(in) {
+		return in.Obstacles
+	}
+	rings := []*pb.Obstacle{in.Boundary}
 	for _, obs := range in.Obstacles {
-		if len(obs.Points) < 3 {
+		if len(obs.Points) > 0 && !ringContains(in.Boundary.Points, obs.Points[0]) {
 			continue
 		}
-		for i := 0; i < len(obs.Points); i++ {
-			p1 := obs.Points[i]
-			p2 := obs.Points[(i+1)%len(obs.Points)]
-
-			idx1 := getIdx(p1)
-			idx2 := getIdx(p2)
+		rings = append(rings, obs)
+	}
+	return rings
+}
 
-			if idx1 != -1 && idx2 != -1 {
-				err := dt.AddConstraint(idx1, idx2)
-				if err != nil {
-					log.Warn().Err(err).Msg("Failed to add constraint")
-				}
+// holeRings returns the rings marked as holes, keyed by nesting depth. A
+// ring nested inside k other rings has depth k+1, matching Triangle.Depth.
+func holeRings(obstacles []*pb.Obstacle) map[int32][][]*pb.Point {
+	holes := make(map[int32][][]*pb.Point)
+	for i, obs := range obstacles {
+		if !obs.GetHole() || len(obs.Points) < 3 {
+			continue
+		}
+		depth := int32(1)
+		for j, other := range obstacles {
+			if j != i && len(other.Points) >= 3 && ringContains(other.Points, obs.Points[0]) {
+				depth++
 			}
 		}
+		holes[depth] = append(holes[depth], obs.Points)
 	}
+	return holes
+}
 
-	// Carve outside triangles
-	dt.ClassifyRegions()
-
-	var triangles []*pb.Triangle
-	for _, t := range dt.Triangles {
-		p1 := dt.Points[t.A]
-		p2 := dt.Points[t.B]
-		p3 := dt.Points[t.C]
+// evenOddKeeps reports whether classifyMesh marks triangle t Inside: it lies at an odd
+// depth, and not directly inside a ring marked as a hole.
+func evenOddKeeps(dt *algo.Delaunay, t algo.Triangle, holes map[int32][][]*pb.Point) bool {
+	return t.Depth%2 == 1 && !inHole(dt, t, holes)
+}
 
-		triangles = append(triangles, &pb.Triangle{
-			A:                &pb.Point{X: p1.X, Y: p1.Y},
-			B:                &pb.Point{X: p2.X, Y: p2.Y},
-			C:                &pb.Point{X: p3.X, Y: p3.Y},
-			ConstrainedEdges: []bool{t.Constrained[0], t.Constrained[1], t.Constrained[2]},
-		})
+// inHole reports whether triangle t lies directly inside a ring marked as a
+// hole. Rings equally deep cannot contain one another, so only the holes at
+// the triangle's own depth are tested, against its centroid.
+func inHole(dt *algo.Delaunay, t algo.Triangle, holes map[int32][][]*pb.Point) bool {
+	rings := holes[t.Depth]
+	if len(rings) == 0 {
+		return false
+	}
+	a, b, c := dt.Points[t.A], dt.Points[t.B], dt.Points[t.C]
+	centroid := &pb.Point{X: (a.X + b.X + c.X) / 3, Y: (a.Y + b.Y + c.Y) / 3}
+	for _, ring := range rings {
+		if ringContains(ring, centroid) {
+			return true
+		}
 	}
+	return false
+}
 
-	return &pb.TriangulationResult{Triangles: triangles}, nil
+// ringContains reports whether p lies inside the ring (crossing number test).
+func ringContains(ring []*pb.Point, p *pb.Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
 }
 
 func (s *server) SaveMap(ctx context.Context, in *pb.MapData) (*pb.SaveMapResponse, error) {
-	log.Info().Msg("Received SaveMap request")
-	return &pb.SaveMapResponse{Success: true, Message: "Map saved successfully (mock)"}, nil
+	log.Info().Str("map_id", in.MapId).Msg("Received SaveMap request")
+
+	if s.maps == nil {
+		return nil, errNoMapStore
+	}
+
+	// The ID and name live on the StoredMap; LoadMap copies them back.
+	data := proto.Clone(in).(*pb.MapData)
+	data.MapId, data.Name = "", ""
+	stored := &pb.StoredMap{MapId: in.MapId, Name: in.Name, Map: data}
+
+	// Cache the triangulation; a map that cannot be meshed is still saved
+	dt, err := buildMesh(ctx, in, nil)
+	switch {
+	case ctx.Err() != nil:
+		return nil, contextStatus(ctx.Err())
+	case err != nil:
+		log.Warn().Err(err).Msg("Saving map without a cached triangulation")
+	case dt != nil:
+		stored.Mesh = triangulationResult(dt, in, inputVertices(dt, in))
+	}
+
+	saved, err := s.maps.Save(ctx, stored)
+	if err != nil {
+		return nil, storeStatus(err)
+	}
+	return &pb.SaveMapResponse{Success: true, Message: "Map saved", MapId: saved.MapId}, nil
+}
+
+func (s *server) LoadMap(ctx context.Context, in *pb.MapRequest) (*pb.StoredMap, error) {
+	log.Info().Str("map_id", in.MapId).Msg("Received LoadMap request")
+
+	if s.maps == nil {
+		return nil, errNoMapStore
+	}
+	stored, err := s.maps.Load(ctx, in.MapId)
+	if err != nil {
+		return nil, storeStatus(err)
+	}
+	if stored.Map == nil {
+		stored.Map = &pb.MapData{}
+	}
+	stored.Map.MapId, stored.Map.Name = stored.MapId, stored.Name
+	return stored, nil
+}
+
+func (s *server) ListMaps(ctx context.Context, in *pb.ListMapsRequest) (*pb.MapList, error) {
+	log.Info().Msg("Received ListMaps request")
+
+	if s.maps == nil {
+		return nil, errNoMapStore
+	}
+	maps, err := s.maps.List(ctx)
+	if err != nil {
+		return nil, storeStatus(err)
+	}
+	return &pb.MapList{Maps: maps}, nil
+}
+
+func (s *server) DeleteMap(ctx context.Context, in *pb.MapRequest) (*pb.DeleteMapResponse, error) {
+	log.Info().Str("map_id", in.MapId).Msg("Received DeleteMap request")
+
+	if s.maps == nil {
+		return nil, errNoMapStore
+	}
+	if err := s.maps.Delete(ctx, in.MapId); err != nil {
+		return nil, storeStatus(err)
+	}
+	return &pb.DeleteMapResponse{MapId: in.MapId}, nil
+}
+
+var errNoMapStore = status.Error(codes.FailedPrecondition, "no map store is configured")
+
+// storeStatus converts a map store error to a gRPC status.
+func storeStatus(err error) error {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return contextStatus(err)
+	}
+	log.Err(err).Msg("Map store failed")
+	return status.Error(codes.Internal, err.Error())
 }
@@ -3,14 +3,17 @@ package server
 import (
 	"fmt"
 	"net"
+	"time"
 
+	"github.com/ORBWARRIOR/PolyNav/backend/internal/store"
 	pb "github.com/ORBWARRIOR/PolyNav/backend/pkg/proto"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 )
 
 type GrpcServer struct {
-	server *grpc.Server
+	server   *grpc.Server
+	sessions *sessionManager
 }
 
 func (s *GrpcServer) Run(webAddress string, errorChannel chan<- error) {
@@ -33,10 +36,58 @@ func (s *GrpcServer) Run(webAddress string, errorChannel chan<- error) {
 func (server *GrpcServer) Shutdown() {
 	log.Info().Msg("Shutting down gRPC server...")
 	server.server.GracefulStop()
+	server.sessions.close()
 }
 
-func NewServer() (*GrpcServer, error) {
+// Option configures the geometry service.
+type Option func(*server)
+
+// WithDebug enables debugging RPCs such as ValidateMesh.
+func WithDebug(enabled bool) Option {
+	return func(s *server) {
+		s.debug = enabled
+	}
+}
+
+// WithMapStore enables SaveMap, LoadMap, ListMaps and DeleteMap, backed by
+// repo.
+func WithMapStore(repo store.Repository) Option {
+	return func(s *server) {
+		s.maps = repo
+	}
+}
+
+// WithSessionTTL sets how long an editing session may go without a call
+// before it expires; the default is 30 minutes.
+func WithSessionTTL(ttl time.Duration) Option {
+	return func(s *server) {
+		s.sessionTTL = ttl
+	}
+}
+
+// WithMaxSessions sets how many editing sessions may be open at once;
+// CreateSession fails with ResourceExhausted beyond it. The default is 1000.
+func WithMaxSessions(n int) Option {
+	return func(s *server) {
+		s.maxSessions = n
+	}
+}
+
+func NewServer(opts ...Option) (*GrpcServer, error) {
+	svc := &server{sessionTTL: defaultSessionTTL, maxSessions: defaultMaxSessions}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	if svc.sessionTTL <= 0 {
+		return nil, fmt.Errorf("session TTL must be positive, got %v", svc.sessionTTL)
+	}
+	if svc.maxSessions <= 0 {
+		return nil, fmt.Errorf("session limit must be positive, got %d", svc.maxSessions)
+	}
+	svc.sessions = newSessionManager(svc.sessionTTL, svc.maxSessions)
+	go svc.sessions.run()
+
 	s := grpc.NewServer()
-	pb.RegisterGeometryServiceServer(s, &server{})
-	return &GrpcServer{server: s}, nil
+	pb.RegisterGeometryServiceServer(s, svc)
+	return &GrpcServer{server: s, sessions: svc.sessions}, nil
 }
@@ -0,0 +1,459 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ORBWARRIOR/PolyNav/backend/internal/algo"
+	pb "github.com/ORBWARRIOR/PolyNav/backend/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultSessionTTL is how long a session may go without a call before it
+// expires.
+const defaultSessionTTL = 30 * time.Minute
+
+// defaultMaxSessions is how many sessions may be open at once.
+const defaultMaxSessions = 1000
+
+// session is a map whose constrained mesh stays in memory between calls.
+// The mesh is never classified: GetMesh classifies a copy, so obstacles can
+// still be added and removed afterwards.
+type session struct {
+	mu        sync.Mutex
+	dt        *algo.Delaunay
+	in        *pb.MapData // The map as created; obstacles live in obstacles
+	boundary  []int
+	ends      []int // Start then goal vertices, for those the map has
+	obstacles map[int32]*sessionObstacle
+	nextID    int32
+}
+
+// sessionObstacle is an obstacle ring with the mesh vertex of each point.
+type sessionObstacle struct {
+	ring  *pb.Obstacle
+	verts []int
+}
+
+// sessionManager holds the open sessions. Its lock covers the table and
+// each session's last use; each session's own lock covers its mesh.
+type sessionManager struct {
+	mu       sync.Mutex
+	sessions map[string]*session
+	lastUsed map[string]time.Time
+	ttl      time.Duration
+	limit    int
+	now      func() time.Time
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newSessionManager(ttl time.Duration, maxSessions int) *sessionManager {
+	return &sessionManager{
+		sessions: make(map[string]*session),
+		lastUsed: make(map[string]time.Time),
+		ttl:      ttl,
+		limit:    maxSessions,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+	}
+}
+
+// run expires idle sessions until close is called.
+func (m *sessionManager) run() {
+	ticker := time.NewTicker(max(m.ttl/4, time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.expire()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+func (m *sessionManager) close() {
+	m.stopOnce.Do(func() { close(m.stop) })
+}
+
+// add opens session s, refusing it once the limit is reached. Sessions that
+// have expired but not yet been dropped do not count.
+func (m *sessionManager) add(s *session) (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", status.Errorf(codes.Internal, "failed to generate session ID: %v", err)
+	}
+	id := hex.EncodeToString(b[:])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.sessions) >= m.limit {
+		m.dropExpired()
+	}
+	if len(m.sessions) >= m.limit {
+		return "", status.Errorf(codes.ResourceExhausted, "too many open sessions (limit %d)", m.limit)
+	}
+	m.sessions[id] = s
+	m.lastUsed[id] = m.now()
+	return id, nil
+}
+
+// get returns session id and marks it used. Expired sessions are not found
+// even if the janitor has yet to drop them.
+func (m *sessionManager) get(id string) (*session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok || m.now().Sub(m.lastUsed[id]) > m.ttl {
+		m.drop(id)
+		return nil, status.Errorf(codes.NotFound, "session %q not found", id)
+	}
+	m.lastUsed[id] = m.now()
+	return s, nil
+}
+
+func (m *sessionManager) remove(id string) error {
+	if _, err := m.get(id); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.drop(id)
+	return nil
+}
+
+// expire drops every session idle for longer than the TTL. A call already
+// holding one finishes on it, but no later call can find it.
+func (m *sessionManager) expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dropExpired()
+}
+
+// dropExpired forgets every session idle for longer than the TTL; the
+// caller holds the lock.
+func (m *sessionManager) dropExpired() {
+	now := m.now()
+	for id, used := range m.lastUsed {
+		if now.Sub(used) > m.ttl {
+			log.Info().Str("session_id", id).Msg("Session expired")
+			m.drop(id)
+		}
+	}
+}
+
+// drop forgets session id; the caller holds the lock.
+func (m *sessionManager) drop(id string) {
+	delete(m.sessions, id)
+	delete(m.lastUsed, id)
+}
+
+func (s *server) CreateSession(ctx context.Context, in *pb.MapData) (*pb.SessionInfo, error) {
+	log.Info().Int("obstacles", len(in.Obstacles)).Msg("Received CreateSession request")
+
+	rings := mapRings(in)
+	dt, ringVerts, err := constrainMesh(ctx, in, rings, func(pb.Stage, int, int, *algo.Delaunay) {})
+	if err != nil {
+		return nil, err
+	}
+	if dt == nil {
+		return nil, status.Error(codes.InvalidArgument, "a session needs at least 3 points")
+	}
+
+	sess := &session{
+		dt:        dt,
+		in:        proto.Clone(in).(*pb.MapData),
+		obstacles: make(map[int32]*sessionObstacle),
+	}
+	sess.in.Obstacles = nil
+
+	// The boundary, start and goal never move, so their vertices are never
+	// released. Start and goal follow the ring points in the mesh's input.
+	input := 0
+	for _, verts := range ringVerts {
+		input += len(verts)
+	}
+	for _, p := range []*pb.Point{in.Start, in.Goal} {
+		if p != nil {
+			sess.ends = append(sess.ends, dt.InputVertex(input))
+			input++
+		}
+	}
+	if hasBoundary(in) {
+		sess.boundary = ringVerts[0]
+		rings, ringVerts = rings[1:], ringVerts[1:]
+	}
+
+	// Obstacles mapRings dropped keep ID -1
+	info := &pb.SessionInfo{}
+	kept := 0
+	for _, obs := range in.Obstacles {
+		if kept < len(rings) && obs == rings[kept] {
+			info.ObstacleIds = append(info.ObstacleIds, sess.register(obs, ringVerts[kept]))
+			kept++
+		} else {
+			info.ObstacleIds = append(info.ObstacleIds, -1)
+		}
+	}
+
+	if info.SessionId, err = s.sessions.add(sess); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (s *server) AddObstacle(ctx context.Context, in *pb.AddObstacleRequest) (*pb.ObstacleRef, error) {
+	log.Info().Str("session_id", in.SessionId).Msg("Received AddObstacle request")
+
+	sess, err := s.sessions.get(in.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	ring := in.GetObstacle()
+	if len(ring.GetPoints()) < 3 {
+		return nil, status.Error(codes.InvalidArgument, "an obstacle needs at least 3 points")
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if err := sess.checkInside(ring); err != nil {
+		return nil, err
+	}
+
+	verts := make([]int, 0, len(ring.Points))
+	for _, p := range ring.Points {
+		v, err := sess.dt.InsertPoint(algo.Point{X: p.X, Y: p.Y})
+		if err != nil {
+			sess.release(verts)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		verts = append(verts, v)
+	}
+	// An edit is small and must not be left half applied, so it runs to
+	// completion even if the client gives up
+	constrainRing(context.WithoutCancel(ctx), sess.dt, verts, nil)
+
+	id := sess.register(proto.Clone(ring).(*pb.Obstacle), verts)
+	return &pb.ObstacleRef{SessionId: in.SessionId, ObstacleId: id}, nil
+}
+
+func (s *server) RemoveObstacle(ctx context.Context, in *pb.ObstacleRef) (*pb.ObstacleRef, error) {
+	log.Info().Str("session_id", in.SessionId).Int32("obstacle_id", in.ObstacleId).Msg("Received RemoveObstacle request")
+
+	sess, err := s.sessions.get(in.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	obs, err := sess.obstacle(in.ObstacleId)
+	if err != nil {
+		return nil, err
+	}
+	if err := sess.dt.RemoveObstacle(obs.verts, sess.neighbours(obs)...); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	delete(sess.obstacles, in.ObstacleId)
+	sess.release(obs.verts)
+	return in, nil
+}
+
+func (s *server) MovePoint(ctx context.Context, in *pb.MovePointRequest) (*pb.ObstacleRef, error) {
+	log.Info().Str("session_id", in.SessionId).Int32("obstacle_id", in.ObstacleId).Msg("Received MovePoint request")
+
+	sess, err := s.sessions.get(in.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	obs, err := sess.obstacle(in.ObstacleId)
+	if err != nil {
+		return nil, err
+	}
+	i := int(in.PointIndex)
+	if i < 0 || i >= len(obs.verts) || in.To == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "obstacle %d has no point %d", in.ObstacleId, in.PointIndex)
+	}
+	ring := proto.Clone(obs.ring).(*pb.Obstacle)
+	ring.Points[i] = &pb.Point{X: in.To.X, Y: in.To.Y}
+	if err := sess.checkInside(ring); err != nil {
+		return nil, err
+	}
+
+	// Lift the obstacle, move the point, then constrain it again
+	if err := sess.dt.RemoveObstacle(obs.verts, sess.neighbours(obs)...); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	ctx = context.WithoutCancel(ctx)
+	v, err := sess.dt.InsertPoint(algo.Point{X: in.To.X, Y: in.To.Y})
+	if err != nil {
+		constrainRing(ctx, sess.dt, obs.verts, nil)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	old := obs.verts[i]
+	obs.ring, obs.verts[i] = ring, v
+	sess.release([]int{old})
+	constrainRing(ctx, sess.dt, obs.verts, nil)
+	return &pb.ObstacleRef{SessionId: in.SessionId, ObstacleId: in.ObstacleId}, nil
+}
+
+func (s *server) GetMesh(ctx context.Context, in *pb.SessionRequest) (*pb.TriangulationResult, error) {
+	log.Info().Str("session_id", in.SessionId).Msg("Received GetMesh request")
+
+	sess, err := s.sessions.get(in.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Classify a copy outside the lock, so edits are not held up by refinement
+	sess.mu.Lock()
+	dt, current, inputs := sess.dt.Clone(), sess.currentMap(), sess.inputVertices()
+	sess.mu.Unlock()
+
+	err = classifyMesh(ctx, dt, current, mapRings(current), func(pb.Stage, int, int, *algo.Delaunay) {})
+	if err != nil {
+		return nil, err
+	}
+	return triangulationResult(dt, current, inputs), nil
+}
+
+func (s *server) CloseSession(ctx context.Context, in *pb.SessionRequest) (*pb.SessionInfo, error) {
+	log.Info().Str("session_id", in.SessionId).Msg("Received CloseSession request")
+
+	if err := s.sessions.remove(in.SessionId); err != nil {
+		return nil, err
+	}
+	return &pb.SessionInfo{SessionId: in.SessionId}, nil
+}
+
+// register adds an obstacle already constrained into the mesh and returns
+// its new ID.
+func (s *session) register(ring *pb.Obstacle, verts []int) int32 {
+	id := s.nextID
+	s.nextID++
+	s.obstacles[id] = &sessionObstacle{ring: ring, verts: verts}
+	return id
+}
+
+func (s *session) obstacle(id int32) (*sessionObstacle, error) {
+	obs, ok := s.obstacles[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "obstacle %d not found", id)
+	}
+	return obs, nil
+}
+
+// neighbours returns the vertex rings that may share walls with obs: the
+// boundary and the other obstacles whose bounding boxes meet its own.
+// Removing obs leaves their walls constrained.
+func (s *session) neighbours(obs *sessionObstacle) [][]int {
+	var rings [][]int
+	if s.boundary != nil {
+		rings = append(rings, s.boundary)
+	}
+	lo, hi := ringBounds(obs.ring.Points)
+	for _, other := range s.obstacles {
+		if other == obs {
+			continue
+		}
+		otherLo, otherHi := ringBounds(other.ring.Points)
+		if otherLo.X <= hi.X && lo.X <= otherHi.X && otherLo.Y <= hi.Y && lo.Y <= otherHi.Y {
+			rings = append(rings, other.verts)
+		}
+	}
+	return rings
+}
+
+// ringBounds returns the corners of a ring's bounding box.
+func ringBounds(ring []*pb.Point) (lo, hi algo.Point) {
+	lo = algo.Point{X: math.Inf(1), Y: math.Inf(1)}
+	hi = algo.Point{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, p := range ring {
+		lo = algo.Point{X: min(lo.X, p.X), Y: min(lo.Y, p.Y)}
+		hi = algo.Point{X: max(hi.X, p.X), Y: max(hi.Y, p.Y)}
+	}
+	return lo, hi
+}
+
+// checkInside refuses an obstacle outside the boundary, which mapRings would
+// ignore but the mesh would still be constrained by.
+func (s *session) checkInside(ring *pb.Obstacle) error {
+	if hasBoundary(s.in) && !ringContains(s.in.Boundary.Points, ring.Points[0]) {
+		return status.Error(codes.InvalidArgument, "obstacle lies outside the boundary")
+	}
+	return nil
+}
+
+// release removes the vertices no other ring, start or goal uses. A vertex
+// RemovePoint refuses, such as one where another obstacle's constraint was
+// split, stays in the mesh unconstrained.
+func (s *session) release(verts []int) {
+	inUse := make(map[int]bool)
+	for _, v := range slices.Concat(s.boundary, s.ends) {
+		inUse[v] = true
+	}
+	for _, obs := range s.obstacles {
+		for _, v := range obs.verts {
+			inUse[v] = true
+		}
+	}
+	for _, v := range verts {
+		if v == -1 || inUse[v] {
+			continue
+		}
+		inUse[v] = true // Repeated points are removed once
+		if err := s.dt.RemovePoint(v); err != nil {
+			log.Debug().Err(err).Int("vertex", v).Msg("Keeping released vertex")
+		}
+	}
+}
+
+// inputVertices is inputVertices for currentMap: the vertices of the
+// obstacles in ID order, the boundary, then start and goal. A boundary too
+// short to be constrained has no vertices, so its points map to -1.
+func (s *session) inputVertices() []int {
+	var inputs []int
+	for _, id := range s.obstacleIDs() {
+		inputs = append(inputs, s.obstacles[id].verts...)
+	}
+	if s.boundary != nil {
+		inputs = append(inputs, s.boundary...)
+	} else {
+		for range s.in.GetBoundary().GetPoints() {
+			inputs = append(inputs, -1)
+		}
+	}
+	return append(inputs, s.ends...)
+}
+
+// currentMap returns the map as edited: the original with its obstacles
+// replaced by the session's, in ID order.
+func (s *session) currentMap() *pb.MapData {
+	current := proto.Clone(s.in).(*pb.MapData)
+	for _, id := range s.obstacleIDs() {
+		current.Obstacles = append(current.Obstacles, s.obstacles[id].ring)
+	}
+	return current
+}
+
+// obstacleIDs returns the IDs of the session's obstacles in order.
+func (s *session) obstacleIDs() []int32 {
+	ids := make([]int32, 0, len(s.obstacles))
+	for id := range s.obstacles {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
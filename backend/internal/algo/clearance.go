@@ -0,0 +1,175 @@
+package algo
+
+import "math"
+
+// triangleWidth returns the widest agent that can cross triangle tIdx from
+// edge slot entry to edge slot exit, following Demyen & Buro's Triangulation
+// A* ("Efficient Triangulation-Based Pathfinding", AAAI 2006).
+// The two edges share vertex C; the width is bounded by the distance from C
+// to the other two vertices and by the nearest wall beyond the edge opposite
+// C, found by searchWidth. Boundary edges count as walls.
+//
+// Demyen assumes every vertex is an obstacle corner. Here vertices that touch
+// no wall (start/goal or Steiner points) do not bound the width, and the
+// search stops once the width reaches limit, since only "wide enough" matters.
+func (d *Delaunay) triangleWidth(tIdx, entry, exit int, walls []bool, limit float64) float64 {
+	t := d.Triangles[tIdx]
+	verts := [3]int32{t.A, t.B, t.C}
+	cSlot := 3 - entry - exit
+
+	c := d.Points[verts[cSlot]]
+	a := d.Points[verts[entry]]
+	b := d.Points[verts[exit]]
+
+	width := limit
+	if walls[verts[cSlot]] {
+		if walls[verts[entry]] {
+			width = math.Min(width, dist(c, a))
+		}
+		if walls[verts[exit]] {
+			width = math.Min(width, dist(c, b))
+		}
+	}
+	if isObtuse(c, a, b) || isObtuse(c, b, a) {
+		return width
+	}
+	if d.isWall(tIdx, cSlot) {
+		return math.Min(width, distToSegment(c, a, b))
+	}
+	return d.searchWidth(c, tIdx, cSlot, width)
+}
+
+// searchWidth looks across edge slot of tIdx for walls closer to c than the
+// current bound, recursing into the neighbour while the edge is within range.
+func (d *Delaunay) searchWidth(c Point, tIdx, slot int, bound float64) float64 {
+	t := d.Triangles[tIdx]
+	verts := [3]int32{t.A, t.B, t.C}
+	u := d.Points[verts[(slot+1)%3]]
+	v := d.Points[verts[(slot+2)%3]]
+
+	// Anything beyond an edge seen at an obtuse angle is further than its endpoints.
+	if isObtuse(c, u, v) || isObtuse(c, v, u) {
+		return bound
+	}
+	edgeDist := distToSegment(c, u, v)
+	if edgeDist > bound {
+		return bound
+	}
+	if d.isWall(tIdx, slot) {
+		return edgeDist
+	}
+
+	nIdx := d.getNeighborIdx(EdgeRef{TIdx: tIdx, EdgeIdx: slot})
+	entry := d.neighborSlot(nIdx, tIdx)
+	bound = d.searchWidth(c, nIdx, (entry+1)%3, bound)
+	return d.searchWidth(c, nIdx, (entry+2)%3, bound)
+}
+
+// wallVertices marks every vertex that is an endpoint of a wall edge.
+func (d *Delaunay) wallVertices() []bool {
+	walls := make([]bool, len(d.Points))
+	for i, t := range d.Triangles {
+		if !t.Active {
+			continue
+		}
+		verts := [3]int32{t.A, t.B, t.C}
+		for slot := 0; slot < 3; slot++ {
+			if d.isWall(i, slot) {
+				walls[verts[(slot+1)%3]] = true
+				walls[verts[(slot+2)%3]] = true
+			}
+		}
+	}
+	return walls
+}
+
+// pointClearance is the distance from p, inside triangle tIdx, to the nearest
+// wall, or limit if every wall is further. Like searchWidth it only crosses
+// edges closer to p than the best distance so far, so the search stays within
+// limit of p.
+func (d *Delaunay) pointClearance(tIdx int, p Point, limit float64) float64 {
+	clearance := limit
+	visited := map[int]bool{tIdx: true}
+	stack := []int{tIdx}
+	for len(stack) > 0 {
+		curr := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		t := d.Triangles[curr]
+		verts := [3]int32{t.A, t.B, t.C}
+		for slot := 0; slot < 3; slot++ {
+			u, v := d.Points[verts[(slot+1)%3]], d.Points[verts[(slot+2)%3]]
+			edgeDist := distToSegment(p, u, v)
+			if edgeDist >= clearance {
+				continue
+			}
+			if d.isWall(curr, slot) {
+				clearance = edgeDist
+				continue
+			}
+			if nIdx := d.getNeighborIdx(EdgeRef{TIdx: curr, EdgeIdx: slot}); !visited[nIdx] {
+				visited[nIdx] = true
+				stack = append(stack, nIdx)
+			}
+		}
+	}
+	return clearance
+}
+
+// isWall reports whether edge slot of tIdx blocks movement: it is either
+// constrained or on the mesh boundary.
+func (d *Delaunay) isWall(tIdx, slot int) bool {
+	if d.Triangles[tIdx].Constrained[slot] {
+		return true
+	}
+	nIdx := d.getNeighborIdx(EdgeRef{TIdx: tIdx, EdgeIdx: slot})
+	return nIdx == -1 || !d.Triangles[nIdx].Active
+}
+
+// shrinkPortal moves the wall endpoints of the portal from l to r radius
+// towards each other, so a funnel through the shrunk portals passes no closer
+// than radius to them along the portal. A portal too short for both collapses
+// to its midpoint; endpoints touching no wall are left in place.
+func shrinkPortal(l, r Point, lWall, rWall bool, radius float64) (Point, Point) {
+	length := dist(l, r)
+	ux, uy := unit(r.X-l.X, r.Y-l.Y)
+	switch {
+	case lWall && rWall:
+		if length <= 2*radius {
+			mid := Point{(l.X + r.X) / 2, (l.Y + r.Y) / 2}
+			return mid, mid
+		}
+		return Point{l.X + ux*radius, l.Y + uy*radius}, Point{r.X - ux*radius, r.Y - uy*radius}
+	case lWall:
+		s := math.Min(radius, length)
+		return Point{l.X + ux*s, l.Y + uy*s}, r
+	case rWall:
+		s := math.Min(radius, length)
+		return l, Point{r.X - ux*s, r.Y - uy*s}
+	}
+	return l, r
+}
+
+// isObtuse reports whether the angle at corner a of triangle (c, a, b) is >= 90 degrees.
+func isObtuse(c, a, b Point) bool {
+	return (c.X-a.X)*(b.X-a.X)+(c.Y-a.Y)*(b.Y-a.Y) <= 0
+}
+
+func distToSegment(p, a, b Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lenSq := dx*dx + dy*dy
+	if lenSq == 0 {
+		return dist(p, a)
+	}
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / lenSq
+	t = math.Max(0, math.Min(1, t))
+	return dist(p, Point{a.X + t*dx, a.Y + t*dy})
+}
+
+// unit normalises (x, y), returning zero for a zero-length vector.
+func unit(x, y float64) (float64, float64) {
+	l := math.Hypot(x, y)
+	if l == 0 {
+		return 0, 0
+	}
+	return x / l, y / l
+}
@@ -1,12 +1,15 @@
 package algo
 
 import (
+	"context"
 	"fmt"
+	"math"
 )
 
 // EdgeRef uniquely identifies an edge in the mesh.
 // It points to Triangle[TIdx] and the edge opposite vertex EdgeIdx (0=A, 1=B, 2=C).
 // e.g. EdgeIdx=0 means edge BC.
+// As a half-edge it runs from vertex EdgeIdx+1 to EdgeIdx+2 (see halfedge.go).
 type EdgeRef struct {
 	TIdx    int
 	EdgeIdx int
@@ -15,10 +18,23 @@ type EdgeRef struct {
 // AddConstraint enforces a constrained edge between two points.
 // If the points are not already in the mesh, they should be inserted first.
 // This function assumes u and v are indices of existing points.
+// Where u-v crosses an existing constraint, a vertex is inserted at the
+// crossing and both constraints are split there.
 func (d *Delaunay) AddConstraint(u, v int) error {
+	return d.AddConstraintContext(context.Background(), u, v)
+}
+
+// AddConstraintContext is AddConstraint, but returns ctx.Err() soon after
+// ctx is done. The mesh stays valid, but u-v may be missing or only partly
+// constrained and the mesh around it not yet Delaunay.
+func (d *Delaunay) AddConstraintContext(ctx context.Context, u, v int) error {
 	if u == v {
 		return nil
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var created [][2]int32
 	for {
 		edges, splitIdx, err := d.findIntersectingEdges(u, v)
 		if err != nil {
@@ -28,47 +44,293 @@ func (d *Delaunay) AddConstraint(u, v int) error {
 		// If we hit a vertex, split the constraint
 		if splitIdx != -1 {
 			// Constraint u-v is split into u-splitIdx and splitIdx-v
-			if err := d.AddConstraint(u, splitIdx); err != nil {
+			if err := d.AddConstraintContext(ctx, u, splitIdx); err != nil {
 				return err
 			}
-			return d.AddConstraint(splitIdx, v)
+			return d.AddConstraintContext(ctx, splitIdx, v)
 		}
 
 		if len(edges) == 0 {
 			break
 		}
 
-		err = d.resolveIntersections(u, v, edges)
+		// Flips never create constrained edges, so a crossing constraint
+		// shows up on the first pass, before anything has been flipped.
+		for _, e := range edges {
+			if d.Triangles[e.TIdx].Constrained[e.EdgeIdx] {
+				return d.splitCrossing(ctx, u, v, e)
+			}
+		}
+
+		flipped, err := d.resolveIntersections(ctx, u, v, edges)
 		if err != nil {
 			return err
 		}
+		created = append(created, flipped...)
 	}
 
-	// Finally, mark the resulting edge as constrained
+	// Finally, mark the resulting edge as constrained and restore the
+	// Delaunay property around the diagonals the flips created
 	d.markConstraint(u, v)
+	for _, edge := range created {
+		e := d.findEdge(int(edge[0]), int(edge[1]))
+		if e.TIdx != -1 {
+			d.legaliseEdge(e.TIdx, d.getNeighborIdx(e))
+		}
+	}
 	return nil
 }
 
-func (d *Delaunay) resolveIntersections(uIdx, vIdx int, edges []EdgeRef) error {
-	flipped := false
-	for _, e := range edges {
-		if d.isConvex(e) {
-			d.flipEdge(e.TIdx, d.getNeighborIdx(e))
-			flipped = true
-			break
+// AddInputConstraint is AddConstraint between the i-th and j-th points
+// passed to NewDelaunay, wherever deduplication and reordering put them
+// (see InputVertex). Two points merged into one vertex need no constraint.
+func (d *Delaunay) AddInputConstraint(i, j int) error {
+	return d.AddInputConstraintContext(context.Background(), i, j)
+}
+
+// AddInputConstraintContext is AddInputConstraint with the cancellation of
+// AddConstraintContext.
+func (d *Delaunay) AddInputConstraintContext(ctx context.Context, i, j int) error {
+	u, v := d.InputVertex(i), d.InputVertex(j)
+	if u == -1 || v == -1 {
+		return fmt.Errorf("input constraint %d-%d out of range", i, j)
+	}
+	return d.AddConstraintContext(ctx, u, v)
+}
+
+// splitCrossing resolves constraint u-v crossing the constrained edge e by
+// inserting their intersection point and routing both constraints through
+// it. If the intersection is within EPSILON of an existing vertex, that
+// vertex is used instead, so the constraints bend slightly to meet there.
+func (d *Delaunay) splitCrossing(ctx context.Context, u, v int, e EdgeRef) error {
+	a, b := d.Origin(e), d.Dest(e)
+	pU, pV, pA, pB := d.Points[u], d.Points[v], d.Points[a], d.Points[b]
+
+	// The signs are exact and opposite, so s lies strictly between 0 and 1.
+	oU, oV := orient2d(pA, pB, pU), orient2d(pA, pB, pV)
+	s := oU / (oU - oV)
+	x, err := d.InsertPoint(Point{pU.X + s*(pV.X-pU.X), pU.Y + s*(pV.Y-pU.Y)})
+	if err != nil {
+		return fmt.Errorf("failed to insert crossing of %d-%d and %d-%d: %w", u, v, a, b, err)
+	}
+
+	if x != a && x != b {
+		d.freeEdges([][2]int{{a, b}})
+		if err := d.AddConstraintContext(ctx, a, x); err != nil {
+			return err
+		}
+		if err := d.AddConstraintContext(ctx, x, b); err != nil {
+			return err
+		}
+	}
+	if x == u || x == v {
+		// a-b now bends through an endpoint of u-v, so they no longer cross
+		return d.AddConstraintContext(ctx, u, v)
+	}
+	if err := d.AddConstraintContext(ctx, u, x); err != nil {
+		return err
+	}
+	return d.AddConstraintContext(ctx, x, v)
+}
+
+// RemoveConstraint clears the constrained edge u-v, including the pieces it
+// was split into at collinear vertices, and legalises the freed edges and
+// their surroundings so the mesh returns to Delaunay.
+// Region flags are not recomputed; rerun ClassifyRegions if needed.
+func (d *Delaunay) RemoveConstraint(u, v int) error {
+	edges, err := d.constraintEdges(u, v)
+	if err != nil {
+		return err
+	}
+	d.freeEdges(edges)
+	return nil
+}
+
+// RemoveObstacle removes every segment of a closed polygon given as vertex
+// indices. A segment AddConstraint only partly inserted loses the pieces it
+// has, and one never constrained is skipped, so an obstacle can always be
+// lifted. Pieces shared with a polygon in keep stay constrained, so removing
+// one of two obstacles does not open the wall between them. The vertices
+// are checked first, so on error the mesh is unchanged.
+func (d *Delaunay) RemoveObstacle(polygon []int, keep ...[]int) error {
+	for _, v := range polygon {
+		if v < 0 || v >= len(d.Points) {
+			return fmt.Errorf("vertex %d out of range", v)
 		}
 	}
 
-	// If we couldn't flip anything but still have edges, something is wrong
-	if !flipped && len(edges) > 0 {
-		// Sloan (1993) suggests this shouldn't happen for valid input.
-		// However, we'll return an error to avoid infinite loop.
-		return fmt.Errorf("failed to resolve all intersections: stuck")
+	shared := make(map[[2]int]bool)
+	for _, other := range keep {
+		for i := range other {
+			for _, e := range d.constraintPieces(other[i], other[(i+1)%len(other)]) {
+				shared[[2]int{min(e[0], e[1]), max(e[0], e[1])}] = true
+			}
+		}
 	}
 
+	var edges [][2]int
+	for i := range polygon {
+		for _, e := range d.constraintPieces(polygon[i], polygon[(i+1)%len(polygon)]) {
+			if !shared[[2]int{min(e[0], e[1]), max(e[0], e[1])}] {
+				edges = append(edges, e)
+			}
+		}
+	}
+	d.freeEdges(edges)
 	return nil
 }
 
+// constraintPieces returns the pieces of constraint u-v that are in the
+// mesh. A constraint AddConstraint gave up on may be missing its middle, so
+// the chain is followed from both ends. Out of range vertices have none.
+func (d *Delaunay) constraintPieces(u, v int) [][2]int {
+	edges, err := d.constraintEdges(u, v)
+	if err != nil {
+		back, _ := d.constraintEdges(v, u)
+		edges = append(edges, back...)
+	}
+	return edges
+}
+
+// bendTolerance is how far the pieces of a constraint may stray from its
+// straight segment. splitCrossing routes a constraint through a vertex up to
+// EPSILON away on each axis, and a later crossing can bend a piece again.
+const bendTolerance = 8 * EPSILON
+
+// constraintEdges returns the mesh edges making up constraint u-v. Every
+// piece must be constrained. The pieces are found by following constrained
+// edges from u, since AddConstraint splits a constraint at the collinear
+// vertices it meets and bends it through the vertex where another constraint
+// crosses it. At each vertex the chain takes the constrained edge that moves
+// on towards v closest to the segment, so at a crossing it carries straight
+// on rather than turning onto the other constraint. If the chain breaks, the
+// error comes with the pieces followed up to the break.
+func (d *Delaunay) constraintEdges(u, v int) ([][2]int, error) {
+	if u == v {
+		return nil, nil
+	}
+	if u < 0 || v < 0 || u >= len(d.Points) || v >= len(d.Points) {
+		return nil, fmt.Errorf("constraint %d-%d out of range", u, v)
+	}
+
+	pU, pV := d.Points[u], d.Points[v]
+	dx, dy := pV.X-pU.X, pV.Y-pU.Y
+	along := func(w int) float64 { return (d.Points[w].X-pU.X)*dx + (d.Points[w].Y-pU.Y)*dy }
+
+	var edges [][2]int
+	for curr := u; curr != v; {
+		next, nextDist := -1, bendTolerance
+		consider := func(e EdgeRef, w int) {
+			if !d.Triangles[e.TIdx].Constrained[e.EdgeIdx] || along(w) <= along(curr) {
+				return
+			}
+			if dist := distToSegment(d.Points[w], pU, pV); dist <= nextDist {
+				next, nextDist = w, dist
+			}
+		}
+		for e := range d.Star(curr) {
+			consider(e, d.Dest(e))
+			consider(e.Prev(), d.Origin(e.Prev()))
+		}
+		if next == -1 {
+			return edges, fmt.Errorf("constraint %d-%d is not constrained beyond vertex %d", u, v, curr)
+		}
+		edges = append(edges, [2]int{curr, next})
+		curr = next
+	}
+	return edges, nil
+}
+
+// freeEdges clears the constraint flags on both sides of each edge, then
+// legalises every edge of the triangles that touched them.
+func (d *Delaunay) freeEdges(edges [][2]int) {
+	touched := []int{}
+	for _, e := range edges {
+		for _, i := range d.incidentTriangles(e[0]) {
+			if slot := edgeSlot(d.Triangles[i], int32(e[0]), int32(e[1])); slot != -1 {
+				d.Triangles[i].Constrained[slot] = false
+				touched = append(touched, i)
+			}
+		}
+	}
+
+	// flipEdge reuses both triangle slots, so touched indices stay valid.
+	for _, tIdx := range touched {
+		for slot := 0; slot < 3; slot++ {
+			n := d.getNeighborIdx(EdgeRef{tIdx, slot})
+			if n != -1 && d.neighborSlot(n, tIdx) != -1 {
+				d.legaliseEdge(tIdx, n)
+			}
+		}
+	}
+}
+
+// findEdge returns an active triangle and slot for edge u-v, or TIdx -1.
+func (d *Delaunay) findEdge(u, v int) EdgeRef {
+	for _, i := range d.incidentTriangles(u) {
+		if slot := edgeSlot(d.Triangles[i], int32(u), int32(v)); slot != -1 {
+			return EdgeRef{TIdx: i, EdgeIdx: slot}
+		}
+	}
+	return EdgeRef{TIdx: -1, EdgeIdx: -1}
+}
+
+// resolveIntersections flips the edges crossing u-v using Sloan's queue:
+// an edge whose quad is not convex goes to the back, and so does a flipped
+// diagonal that still crosses u-v. Edges are tracked by their vertices since
+// flips rewrite triangle slots. The new diagonals clear of u-v are returned
+// for legalisation.
+func (d *Delaunay) resolveIntersections(ctx context.Context, uIdx, vIdx int, edges []EdgeRef) ([][2]int32, error) {
+	pU, pV := d.Points[uIdx], d.Points[vIdx]
+
+	queue := make([][2]int32, 0, len(edges))
+	for _, e := range edges {
+		queue = append(queue, [2]int32{int32(d.Origin(e)), int32(d.Dest(e))})
+	}
+
+	var created [][2]int32
+	stalled := 0
+	for i := 0; len(queue) > 0; i++ {
+		if i%cancelInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		// A full pass without a flip means no progress is possible.
+		if stalled > len(queue) {
+			return nil, fmt.Errorf("failed to resolve all intersections: stuck")
+		}
+
+		edge := queue[0]
+		queue = queue[1:]
+
+		e := d.findEdge(int(edge[0]), int(edge[1]))
+		if e.TIdx == -1 {
+			continue // Already flipped away
+		}
+		if !d.isConvex(e) {
+			queue = append(queue, edge)
+			stalled++
+			continue
+		}
+
+		twin := d.Twin(e)
+		p, q := int32(d.Apex(e)), int32(d.Apex(twin))
+
+		d.flipEdge(e.TIdx, twin.TIdx)
+		stalled = 0
+
+		if int(p) != uIdx && int(p) != vIdx && int(q) != uIdx && int(q) != vIdx &&
+			segmentsIntersect(pU, pV, d.Points[p], d.Points[q]) {
+			queue = append(queue, [2]int32{p, q})
+		} else {
+			created = append(created, [2]int32{p, q})
+		}
+	}
+
+	return created, nil
+}
+
 func (d *Delaunay) getNeighborIdx(e EdgeRef) int {
 	t := d.Triangles[e.TIdx]
 	if e.EdgeIdx == 0 {
@@ -81,156 +343,145 @@ func (d *Delaunay) getNeighborIdx(e EdgeRef) int {
 }
 
 func (d *Delaunay) isConvex(e EdgeRef) bool {
-	nIdx := d.getNeighborIdx(e)
-	if nIdx == -1 {
+	twin := d.Twin(e)
+	if twin.TIdx == -1 {
 		return false
 	}
 
-	t := d.Triangles[e.TIdx]
-	n := d.Triangles[nIdx]
-
-	// Vertices of shared edge
-	var u, v Point
-	if e.EdgeIdx == 0 {
-		u, v = d.Points[t.B], d.Points[t.C]
-	} else if e.EdgeIdx == 1 {
-		u, v = d.Points[t.C], d.Points[t.A]
-	} else {
-		u, v = d.Points[t.A], d.Points[t.B]
-	}
-
-	// Opposite vertices
-	p := d.Points[[3]int32{t.A, t.B, t.C}[e.EdgeIdx]]
-
-	var nSlot int
-	if int(n.T1) == e.TIdx {
-		nSlot = 0
-	} else if int(n.T2) == e.TIdx {
-		nSlot = 1
-	} else {
-		nSlot = 2
-	}
-	q := d.Points[[3]int32{n.A, n.B, n.C}[nSlot]]
+	// Vertices of shared edge, and the opposite vertices
+	u, v := d.Points[d.Origin(e)], d.Points[d.Dest(e)]
+	p, q := d.Points[d.Apex(e)], d.Points[d.Apex(twin)]
 
 	// A quad is convex if the opposite vertices p and q lie on opposite sides of uv,
-	// AND u and v lie on opposite sides of pq.
-	// Relaxed check: Allow collinearity/degeneracy to avoid getting stuck.
-	return d.orient2d(u, v, p)*d.orient2d(u, v, q) <= EPSILON &&
-		d.orient2d(p, q, u)*d.orient2d(p, q, v) <= EPSILON
+	// AND u and v lie on opposite sides of pq. The signs are exact, so a
+	// degenerate quad is left for the queue instead of being flipped flat.
+	return oppositeSigns(d.orient2d(u, v, p), d.orient2d(u, v, q)) &&
+		oppositeSigns(d.orient2d(p, q, u), d.orient2d(p, q, v))
 }
 
 func (d *Delaunay) markConstraint(u, v int) {
 	// Find the triangle(s) sharing edge uv and set Constrained bit
-	for i, t := range d.Triangles {
-		if !t.Active {
-			continue
-		}
-
-		// Edge 0: BC, 1: CA, 2: AB
-		if (int(t.B) == u && int(t.C) == v) || (int(t.B) == v && int(t.C) == u) {
-			d.Triangles[i].Constrained[0] = true
-		} else if (int(t.C) == u && int(t.A) == v) || (int(t.C) == v && int(t.A) == u) {
-			d.Triangles[i].Constrained[1] = true
-		} else if (int(t.A) == u && int(t.B) == v) || (int(t.A) == v && int(t.B) == u) {
-			d.Triangles[i].Constrained[2] = true
+	for _, i := range d.incidentTriangles(u) {
+		if slot := edgeSlot(d.Triangles[i], int32(u), int32(v)); slot != -1 {
+			d.Triangles[i].Constrained[slot] = true
 		}
 	}
 }
 
-// ClassifyRegions identifies triangles inside and outside the constrained polygons.
+// ClassifyRegions keeps the triangles inside the constrained polygons using
+// the even-odd rule: a polygon nested inside another is a hole, a polygon
+// inside that hole is solid again, and so on. Everything else is removed.
 // It assumes constraints form closed loops.
 func (d *Delaunay) ClassifyRegions() {
-	// 1. Identify "Seed" triangles on the convex hull boundary.
-	// These are guaranteed to be "Outside" if the polygon is internal.
-	// In our case, we'll mark everything as Inside=true by default,
-	// then flood fill from the boundary to mark Inside=false.
+	d.ClassifyRegionsFuncContext(context.Background(), evenOdd)
+}
 
-	for i := range d.Triangles {
-		d.Triangles[i].Inside = true
+// ClassifyRegionsContext is ClassifyRegions, but returns ctx.Err() soon
+// after ctx is done, in which case the mesh is left unchanged.
+func (d *Delaunay) ClassifyRegionsContext(ctx context.Context) error {
+	return d.ClassifyRegionsFuncContext(ctx, evenOdd)
+}
+
+func evenOdd(t Triangle) bool { return t.Depth%2 == 1 }
+
+// ClassifyRegionsFunc sets every triangle's Depth (see ComputeDepths), keeps
+// the triangles for which keep is true and removes the rest. keep sees the
+// whole triangle, so a rule can go beyond the depth: keeping depth > 0
+// treats every polygon as solid regardless of nesting, and testing a
+// triangle's position can tell apart polygons nested equally deep.
+func (d *Delaunay) ClassifyRegionsFunc(keep func(t Triangle) bool) {
+	d.ClassifyRegionsFuncContext(context.Background(), keep)
+}
+
+// ClassifyRegionsFuncContext is ClassifyRegionsFunc with the cancellation
+// of ClassifyRegionsContext.
+func (d *Delaunay) ClassifyRegionsFuncContext(ctx context.Context, keep func(t Triangle) bool) error {
+	if err := d.ComputeDepthsContext(ctx); err != nil {
+		return err
 	}
+	for i, t := range d.Triangles {
+		d.Triangles[i].Inside = t.Active && keep(t)
+	}
+	d.filterTriangles()
+	return nil
+}
+
+// ComputeDepths sets Triangle.Depth to the fewest constrained edges crossed
+// on any walk from outside the hull to the triangle: 0 outside every
+// polygon, 1 inside one, 2 inside a polygon nested in another, and so on.
+// It is a 0-1 BFS: the flood spreads freely across unconstrained edges and
+// only moves to the next depth across constrained ones.
+func (d *Delaunay) ComputeDepths() {
+	d.ComputeDepthsContext(context.Background())
+}
 
-	queue := []int{}
-	visited := make(map[int]bool)
+// ComputeDepthsContext is ComputeDepths, returning ctx.Err() before any
+// depth is written if ctx is done during the flood.
+func (d *Delaunay) ComputeDepthsContext(ctx context.Context) error {
+	depth := make([]int32, len(d.Triangles))
+	for i := range depth {
+		depth[i] = -1
+	}
 
+	// Hull triangles are entered from outside, across a constrained hull
+	// edge if need be.
+	var current, next []int
 	for i, t := range d.Triangles {
 		if !t.Active {
 			continue
 		}
-		// If it has a boundary neighbor, it's a candidate for "Outside"
-		// ONLY if the boundary edge is NOT constrained.
-		if (t.T1 == -1 && !t.Constrained[0]) ||
-			(t.T2 == -1 && !t.Constrained[1]) ||
-			(t.T3 == -1 && !t.Constrained[2]) {
-			queue = append(queue, i)
-			visited[i] = true
-			d.Triangles[i].Inside = false
+		for slot, n := range [3]int32{t.T1, t.T2, t.T3} {
+			if n != -1 {
+				continue
+			}
+			if t.Constrained[slot] {
+				next = append(next, i)
+			} else {
+				current = append(current, i)
+			}
 		}
 	}
 
-	// 2. BFS Flood Fill
-	for len(queue) > 0 {
-		currIdx := queue[0]
-		queue = queue[1:]
-
-		t := d.Triangles[currIdx]
-		neighbors := [3]int32{t.T1, t.T2, t.T3}
-
-		for i, nIdx := range neighbors {
-			if nIdx == -1 {
-				continue
+	steps := 0
+	for k := int32(0); len(current) > 0 || len(next) > 0; k++ {
+		for len(current) > 0 {
+			if steps++; steps%cancelInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
 			}
-			if visited[int(nIdx)] {
+			tIdx := current[len(current)-1]
+			current = current[:len(current)-1]
+			if depth[tIdx] != -1 {
 				continue
 			}
+			depth[tIdx] = k
 
-			// If the edge separating us from the neighbor is constrained,
-			// we do NOT cross it.
-			if t.Constrained[i] {
-				continue
+			t := d.Triangles[tIdx]
+			for slot, n := range [3]int32{t.T1, t.T2, t.T3} {
+				if n == -1 || depth[n] != -1 {
+					continue
+				}
+				if t.Constrained[slot] {
+					next = append(next, int(n))
+				} else {
+					current = append(current, int(n))
+				}
 			}
-
-			// Mark neighbor as outside and add to queue
-			visited[int(nIdx)] = true
-			d.Triangles[int(nIdx)].Inside = false
-			queue = append(queue, int(nIdx))
 		}
+		current, next = next, nil
 	}
 
-	// 3. Remove outside triangles
-	d.filterTriangles()
+	for i := range d.Triangles {
+		d.Triangles[i].Depth = max(depth[i], 0)
+	}
+	return nil
 }
 
 func (d *Delaunay) filterTriangles() {
-	// Map old index to new index
-	newIndices := make([]int32, len(d.Triangles))
-	for i := range newIndices {
-		newIndices[i] = -1
-	}
-
-	activeCount := 0
-	for i, t := range d.Triangles {
-		if t.Active && t.Inside {
-			newIndices[i] = int32(activeCount)
-			activeCount++
-		}
-	}
-
-	newTriangles := make([]Triangle, 0, activeCount)
-	for i, t := range d.Triangles {
-		if newIndices[i] != -1 {
-			updateN := func(n int32) int32 {
-				if n == -1 {
-					return -1
-				}
-				return newIndices[n]
-			}
-			t.T1 = updateN(t.T1)
-			t.T2 = updateN(t.T2)
-			t.T3 = updateN(t.T3)
-			newTriangles = append(newTriangles, t)
-		}
-	}
-	d.Triangles = newTriangles
+	d.compact(func(t Triangle) bool { return t.Inside })
+	d.markSplitFans()
+	d.classified = true
 }
 
 // findIntersectingEdges finds all edges in the triangulation that intersect the segment uv.
@@ -242,35 +493,28 @@ func (d *Delaunay) findIntersectingEdges(u, v int) ([]EdgeRef, int, error) {
 	pU := d.Points[u]
 	pV := d.Points[v]
 
-	// 1. Find a triangle incident to u (using scanning)
+	// 1. Find the triangle around u whose cone holds v
 	var firstIntersectingEdge *EdgeRef
 
-	for i, t := range d.Triangles {
-		if !t.Active {
-			continue
-		}
-
-		var idxA, idxB int32
-		var oppEdgeIdx int
-
-		if int(t.A) == u {
-			idxA, idxB = t.B, t.C
-			oppEdgeIdx = 0
-		} else if int(t.B) == u {
-			idxA, idxB = t.C, t.A
-			oppEdgeIdx = 1
-		} else if int(t.C) == u {
-			idxA, idxB = t.A, t.B
-			oppEdgeIdx = 2
-		} else {
-			continue
-		}
-
+	for _, i := range d.incidentTriangles(u) {
+		out := d.outgoing(i, u)
+		idxA, idxB := d.Dest(out), d.Apex(out)
 		pa, pb := d.Points[idxA], d.Points[idxB]
 
 		// Check if v lies in the cone
-		if d.orient2d(pU, pa, pV) >= -EPSILON && d.orient2d(pU, pb, pV) <= EPSILON {
-			firstIntersectingEdge = &EdgeRef{TIdx: i, EdgeIdx: oppEdgeIdx}
+		if d.orient2d(pU, pa, pV) >= 0 && d.orient2d(pU, pb, pV) <= 0 {
+			// The segment may leave u along one of the cone's edges, in which
+			// case it touches that vertex instead of crossing the opposite edge.
+			for _, k := range [2]int{idxA, idxB} {
+				if k == v {
+					return nil, -1, nil
+				}
+				if d.pointOnSegment(d.Points[k], pU, pV) {
+					return nil, k, nil
+				}
+			}
+			opposite := out.Next()
+			firstIntersectingEdge = &opposite
 			break
 		}
 	}
@@ -284,98 +528,43 @@ func (d *Delaunay) findIntersectingEdges(u, v int) ([]EdgeRef, int, error) {
 	currEdge := *firstIntersectingEdge
 
 	for k := 0; k < len(d.Triangles); k++ {
-		t := d.Triangles[currEdge.TIdx]
-
-		// Get vertices of the edge we are about to cross
-		var p1Idx, p2Idx int32
-		if currEdge.EdgeIdx == 0 {
-			p1Idx, p2Idx = t.B, t.C
-		} else if currEdge.EdgeIdx == 1 {
-			p1Idx, p2Idx = t.C, t.A
-		} else {
-			p1Idx, p2Idx = t.A, t.B
-		}
-
-		// Check if we hit the target v
-		if int(p1Idx) == v || int(p2Idx) == v {
+		// Check if the edge we are about to cross ends at the target v
+		if d.Origin(currEdge) == v || d.Dest(currEdge) == v {
 			return intersectingEdges, -1, nil
 		}
 
 		intersectingEdges = append(intersectingEdges, currEdge)
 
-		var neighborIdx int32
-		if currEdge.EdgeIdx == 0 {
-			neighborIdx = t.T1
-		} else if currEdge.EdgeIdx == 1 {
-			neighborIdx = t.T2
-		} else {
-			neighborIdx = t.T3
-		}
-		if neighborIdx == -1 {
+		entry := d.Twin(currEdge)
+		if entry.TIdx == -1 {
 			return nil, -1, fmt.Errorf("hit boundary before reaching v")
 		}
 
-		nT := d.Triangles[neighborIdx]
-		var entryEdgeIdx int
-		if (nT.B == p1Idx && nT.C == p2Idx) || (nT.B == p2Idx && nT.C == p1Idx) {
-			entryEdgeIdx = 0
-		} else if (nT.C == p1Idx && nT.A == p2Idx) || (nT.C == p2Idx && nT.A == p1Idx) {
-			entryEdgeIdx = 1
-		} else {
-			entryEdgeIdx = 2
-		}
-
-		// Vertex opposite to entry edge in neighbor
-		var oppositeVertexIdx int32
-		if entryEdgeIdx == 0 {
-			oppositeVertexIdx = nT.A
-		} else if entryEdgeIdx == 1 {
-			oppositeVertexIdx = nT.B
-		} else {
-			oppositeVertexIdx = nT.C
-		}
-
-		// Check if this vertex lies on the segment uv
-		if int(oppositeVertexIdx) != v && int(oppositeVertexIdx) != u && d.pointOnSegment(d.Points[oppositeVertexIdx], pU, pV) {
-			return intersectingEdges, int(oppositeVertexIdx), nil
+		// Check if the vertex opposite the entry edge lies on the segment uv
+		oppositeVertexIdx := d.Apex(entry)
+		if oppositeVertexIdx != v && oppositeVertexIdx != u && d.pointOnSegment(d.Points[oppositeVertexIdx], pU, pV) {
+			return intersectingEdges, oppositeVertexIdx, nil
 		}
 
 		// Determine which exit edge to take
-		e1Idx := (entryEdgeIdx + 1) % 3
-		e2Idx := (entryEdgeIdx + 2) % 3
-		getEdgeVerts := func(idx int, tri Triangle) (Point, Point) {
-			if idx == 0 {
-				return d.Points[tri.B], d.Points[tri.C]
-			}
-			if idx == 1 {
-				return d.Points[tri.C], d.Points[tri.A]
-			}
-			return d.Points[tri.A], d.Points[tri.B]
-		}
-
-		pA1, pB1 := getEdgeVerts(e1Idx, nT)
-		pA2, pB2 := getEdgeVerts(e2Idx, nT)
-
-		int1 := segmentsIntersect(pU, pV, pA1, pB1)
-		int2 := segmentsIntersect(pU, pV, pA2, pB2)
-
-		if int1 {
-			currEdge = EdgeRef{TIdx: int(neighborIdx), EdgeIdx: e1Idx}
-		} else if int2 {
-			currEdge = EdgeRef{TIdx: int(neighborIdx), EdgeIdx: e2Idx}
+		exit1, exit2 := entry.Next(), entry.Prev()
+		if segmentsIntersect(pU, pV, d.Points[d.Origin(exit1)], d.Points[d.Dest(exit1)]) {
+			currEdge = exit1
+		} else if segmentsIntersect(pU, pV, d.Points[d.Origin(exit2)], d.Points[d.Dest(exit2)]) {
+			currEdge = exit2
 		} else {
 			// Robustness: If neither edge strictly intersects, we must be hitting the vertex
 			// between them (oppositeVertexIdx). Even if pointOnSegment strict check failed.
 
 			// Prevent infinite recursion if we hit endpoints
-			if int(oppositeVertexIdx) == v {
+			if oppositeVertexIdx == v {
 				return intersectingEdges, -1, nil
 			}
-			if int(oppositeVertexIdx) == u {
+			if oppositeVertexIdx == u {
 				return nil, -1, fmt.Errorf("walk circled back to start")
 			}
 
-			return intersectingEdges, int(oppositeVertexIdx), nil
+			return intersectingEdges, oppositeVertexIdx, nil
 		}
 	}
 	return nil, -1, fmt.Errorf("walk limit exceeded")
@@ -383,28 +572,20 @@ func (d *Delaunay) findIntersectingEdges(u, v int) ([]EdgeRef, int, error) {
 
 func (d *Delaunay) pointOnSegment(p, a, b Point) bool {
 	// Check collinearity
-	if d.orient2d(a, b, p) < -EPSILON || d.orient2d(a, b, p) > EPSILON {
-		return false
-	}
-	// Check bounding box / betweenness
-	// Dot product (p-a).(b-a) should be between 0 and |b-a|^2
-	dp := (p.X-a.X)*(b.X-a.X) + (p.Y-a.Y)*(b.Y-a.Y)
-	if dp < -EPSILON {
-		return false
-	}
-	lenSq := (b.X-a.X)*(b.X-a.X) + (b.Y-a.Y)*(b.Y-a.Y)
-	if dp > lenSq+EPSILON {
+	if d.orient2d(a, b, p) != 0 {
 		return false
 	}
-	return true
+	// Collinear, so betweenness reduces to exact coordinate comparisons
+	return math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
 }
 
 func segmentsIntersect(a, b, c, d Point) bool {
-	o1, o2 := orient(a, b, c), orient(a, b, d)
-	o3, o4 := orient(c, d, a), orient(c, d, b)
-	return ((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) && ((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0))
+	return oppositeSigns(orient2d(a, b, c), orient2d(a, b, d)) &&
+		oppositeSigns(orient2d(c, d, a), orient2d(c, d, b))
 }
 
-func orient(a, b, c Point) float64 {
-	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+// oppositeSigns reports whether x and y are both non-zero with different signs.
+func oppositeSigns(x, y float64) bool {
+	return (x > 0 && y < 0) || (x < 0 && y > 0)
 }
@@ -47,3 +47,56 @@ func (d *Delaunay) DebugJSON() (string, error) {
 	}
 	return string(bytes), nil
 }
+
+// TraceJSON exports recorded trace events with the points they refer to,
+// for stepping through an insertion alongside the DebugJSON mesh.
+func (d *Delaunay) TraceJSON(events []TraceEvent) (string, error) {
+	type Point struct {
+		X float64 `json:"x"`
+		Y float64 `json:"y"`
+	}
+
+	type EventData struct {
+		Kind     string  `json:"kind"`
+		Triangle int     `json:"triangle"`
+		Neighbor int     `json:"neighbour"`
+		Vertices []int32 `json:"vertices"`
+		Target   *Point  `json:"target,omitempty"`
+		Inside   *bool   `json:"inside,omitempty"`
+	}
+
+	type TraceData struct {
+		Points []Point     `json:"points"`
+		Events []EventData `json:"events"`
+	}
+
+	trace := TraceData{
+		Points: make([]Point, 0, len(d.Points)),
+		Events: make([]EventData, 0, len(events)),
+	}
+	for _, p := range d.Points {
+		trace.Points = append(trace.Points, Point{X: p.X, Y: p.Y})
+	}
+	for _, e := range events {
+		data := EventData{
+			Kind:     e.Kind.String(),
+			Triangle: e.Triangle,
+			Neighbor: e.Neighbor,
+			Vertices: e.Vertices,
+		}
+		switch e.Kind {
+		case TraceWalk:
+			data.Target = &Point{X: e.Target.X, Y: e.Target.Y}
+		case TraceInCircle:
+			inside := e.Inside
+			data.Inside = &inside
+		}
+		trace.Events = append(trace.Events, data)
+	}
+
+	bytes, err := json.MarshalIndent(trace, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "%s"}`, err.Error()), err
+	}
+	return string(bytes), nil
+}
@@ -1,30 +1,36 @@
 package algo
 
 import (
+	"context"
 	"errors"
+	"maps"
 	"math"
 	"sort"
 )
 
 // NewDelaunay initialises the mesh with a Super Triangle ensuring convex hull coverage.
-func NewDelaunay(points []Point) (*Delaunay, error) {
+// The options choose the order Triangulate inserts the points in.
+// Duplicate points are merged and the rest reordered, so vertex indices
+// differ from input indices; InputVertex maps one to the other.
+func NewDelaunay(points []Point, opts ...Option) (*Delaunay, error) {
+	o := options{order: OrderSortX, seed: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Input validation for NaN/Inf values
 	if err := validatePoints(points); err != nil {
 		return nil, err
 	}
 
-	uniquePoints := deduplicatePoints(points)
+	uniquePoints, survivors := deduplicatePoints(points)
 
 	if len(uniquePoints) < 3 {
 		return nil, errors.New("insufficient points (needs 3+ unique points)")
 
 	}
 
-	// OPTIMIZATION: "Unidimensional Sorting" approximates spatial binning.
-	// Sorting by X-coordinate improves walking search locality, keeping runtime
-	// closer to O(N^5/4) without implementing full binning.
-	// See docs/ALGORITHMS.md#13-deviations-from-sloans-1987-algorithm
-	sort.Slice(uniquePoints, func(i, j int) bool { return uniquePoints[i].X < uniquePoints[j].X })
+	order := orderPoints(uniquePoints, o)
 
 	// Preallocate with factor 2.5*N (Tuned based on experimental churn)
 	// See docs/MATHEMATICS.md#4-memory-allocation-eulers-formula
@@ -33,7 +39,15 @@ func NewDelaunay(points []Point) (*Delaunay, error) {
 		Triangles: make([]Triangle, 0, int(float64(len(uniquePoints))*2.5)+100),
 	}
 
-	d.Points = append(d.Points, uniquePoints...)
+	vertexOf := make([]int32, len(uniquePoints))
+	for v, idx := range order {
+		d.Points = append(d.Points, uniquePoints[idx])
+		vertexOf[idx] = int32(v)
+	}
+	d.inputVertex = make([]int32, len(points))
+	for i, u := range survivors {
+		d.inputVertex[i] = vertexOf[u]
+	}
 
 	// 1.1.2 Super Triangle (See docs/ALGORITHMS.md#11-the-super-triangle)
 	minX, minY := math.MaxFloat64, math.MaxFloat64
@@ -73,18 +87,80 @@ func NewDelaunay(points []Point) (*Delaunay, error) {
 		Active: true,
 	})
 	d.lastCreated = 0
+	d.vertexTri = make([]int32, 0, len(d.Points))
+	d.indexVertices()
 
 	return d, nil
 }
 
+// InputVertex returns the vertex index of the i-th point passed to
+// NewDelaunay, or -1 if there was no such point. A point merged as a
+// duplicate maps to the vertex of the point it was merged into. The mapping
+// never changes, but RemovePoint can take the vertex out of the mesh.
+func (d *Delaunay) InputVertex(i int) int {
+	if i < 0 || i >= len(d.inputVertex) {
+		return -1
+	}
+	return int(d.inputVertex[i])
+}
+
+// cancelInterval is how many iterations the long loops of the Context
+// methods run between checks of ctx.
+const cancelInterval = 64
+
 // Triangulate executes Incremental Insertion with Lawson's Flip.
 // See docs/ALGORITHMS.md#1-delaunay-triangulation-strategy
 func (d *Delaunay) Triangulate() {
-	originalCount := len(d.Points) - 3
+	d.TriangulateFuncContext(context.Background(), nil)
+}
+
+// TriangulateFunc is Triangulate with a progress hook, called after each
+// point is inserted with the number inserted so far and the total. The mesh
+// is consistent during the call, so the hook may read it (ExportMesh leaves
+// out the super triangle) but must not modify it.
+func (d *Delaunay) TriangulateFunc(progress func(inserted, total int)) {
+	d.TriangulateFuncContext(context.Background(), progress)
+}
+
+// TriangulateContext is Triangulate, but returns ctx.Err() soon after ctx
+// is done. The mesh is then only partly built and should be discarded.
+func (d *Delaunay) TriangulateContext(ctx context.Context) error {
+	return d.TriangulateFuncContext(ctx, nil)
+}
+
+// TriangulateFuncContext is TriangulateFunc with the cancellation of
+// TriangulateContext.
+func (d *Delaunay) TriangulateFuncContext(ctx context.Context, progress func(inserted, total int)) error {
+	// Input points precede the super triangle; anything after it was added
+	// through InsertPoint and is already in the mesh.
+	originalCount := d.superIndices[0]
 	for i := 0; i < originalCount; i++ {
+		if i%cancelInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
 		d.insertPoint(i)
+		if progress != nil {
+			progress(i+1, originalCount)
+		}
 	}
 	d.cleanup()
+	return nil
+}
+
+// Clone returns a deep copy of the mesh, so one copy can be classified or
+// refined while the other keeps being edited. The tracer is not copied.
+func (d *Delaunay) Clone() *Delaunay {
+	c := *d
+	c.Points = append([]Point(nil), d.Points...)
+	c.Triangles = append([]Triangle(nil), d.Triangles...)
+	c.vertexTri = append([]int32(nil), d.vertexTri...)
+	c.splitFans = maps.Clone(d.splitFans)
+	c.free = append([]int32(nil), d.free...)
+	c.inputVertex = append([]int32(nil), d.inputVertex...)
+	c.tracer = nil
+	return &c
 }
 
 // Input validation prevents geometry predicate failures
@@ -100,93 +176,53 @@ func validatePoints(points []Point) error {
 	return nil
 }
 
-// Remove duplicate coordinates using epsilon comparison
-func deduplicatePoints(points []Point) []Point {
+// Remove duplicate coordinates using epsilon comparison. The second result
+// holds, for each input point, the index in the first of the point it was
+// merged into, or of itself if it was kept.
+func deduplicatePoints(points []Point) ([]Point, []int) {
 	if len(points) == 0 {
-		return nil
+		return nil, nil
+	}
+	// Sort indices to group potential duplicates
+	sorted := make([]int, len(points))
+	for i := range sorted {
+		sorted[i] = i
 	}
-	// Sort points to group potential duplicates
-	sorted := make([]Point, len(points))
-	copy(sorted, points)
 	sort.Slice(sorted, func(i, j int) bool {
-		if math.Abs(sorted[i].X-sorted[j].X) > EPSILON {
-			return sorted[i].X < sorted[j].X
+		a, b := points[sorted[i]], points[sorted[j]]
+		if math.Abs(a.X-b.X) > EPSILON {
+			return a.X < b.X
 		}
-		return sorted[i].Y < sorted[j].Y
+		return a.Y < b.Y
 	})
 
 	result := make([]Point, 0, len(points))
-	result = append(result, sorted[0])
-
-	for i := 1; i < len(sorted); i++ {
-		curr := sorted[i]
-		prev := result[len(result)-1]
-
-		if math.Abs(curr.X-prev.X) > EPSILON || math.Abs(curr.Y-prev.Y) > EPSILON {
-			result = append(result, curr)
+	survivors := make([]int, len(points))
+	for _, i := range sorted {
+		curr := points[i]
+		if n := len(result); n > 0 {
+			prev := result[n-1]
+			if math.Abs(curr.X-prev.X) <= EPSILON && math.Abs(curr.Y-prev.Y) <= EPSILON {
+				survivors[i] = n - 1
+				continue
+			}
 		}
+		result = append(result, curr)
+		survivors[i] = len(result) - 1
 	}
-	return result
+	return result, survivors
 }
 
+// cleanup removes the Super Triangle's triangles once every point is in.
 func (d *Delaunay) cleanup() {
-	// Map old index to new index
-	newIndices := make([]int32, len(d.Triangles))
-	for i := range newIndices {
-		newIndices[i] = -1
-	}
-
-	// 1. Mark active/valid triangles and assign new indices
-	activeCount := 0
-	for i, t := range d.Triangles {
-		if !t.Active {
-			continue
-		}
-
-		isSuper := false
-		for _, idx := range []int{int(t.A), int(t.B), int(t.C)} {
-			if idx == d.superIndices[0] || idx == d.superIndices[1] || idx == d.superIndices[2] {
-				isSuper = true
-				break
-			}
-		}
-		
-		// Logic: If it connects to Super Triangle, we remove it. 
-		// Unless we want to keep the Hull? 
-		// Standard cleanup removes them.
-		if !isSuper {
-			newIndices[i] = int32(activeCount)
-			activeCount++
-		}
-	}
-
-	// 2. Compact and Update Neighbors
-	// We create a new slice to avoid overwriting while reading
-	newTriangles := make([]Triangle, 0, activeCount)
-
-	for i, t := range d.Triangles {
-		if newIndices[i] != -1 {
-			// Update neighbors
-			// If a neighbor maps to -1 (removed), it becomes a boundary (-1)
-			
-			updateN := func(n int32) int32 {
-				if n == -1 {
-					return -1
-				}
-				if int(n) >= len(newIndices) {
-					// Should not happen if logic is correct
-					return -1
-				}
-				return newIndices[n]
-			}
+	d.compact(func(t Triangle) bool { return !d.touchesSuper(t) })
+	d.superRemoved = true
+}
 
-			t.T1 = updateN(t.T1)
-			t.T2 = updateN(t.T2)
-			t.T3 = updateN(t.T3)
-			
-			newTriangles = append(newTriangles, t)
-		}
+// remapLastCreated keeps the walk cache valid after triangles are compacted.
+func remapLastCreated(last int, newIndices []int32) int {
+	if last >= 0 && last < len(newIndices) && newIndices[last] != -1 {
+		return int(newIndices[last])
 	}
-	
-	d.Triangles = newTriangles
+	return 0
 }
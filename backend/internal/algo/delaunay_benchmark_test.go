@@ -37,6 +37,243 @@ func BenchmarkDelaunayInsertion(b *testing.B) {
 	}
 }
 
+// BenchmarkInsertionOrder compares the insertion orders on data where
+// sorting by X walks far: tight clusters, and a strip taller than it is wide.
+func BenchmarkInsertionOrder(b *testing.B) {
+	const size = 50000
+	datasets := []struct {
+		name   string
+		points []Point
+	}{
+		{name: "Uniform", points: generateTestPoints(size, 42)},
+		{name: "Clustered", points: generateDenseClusters(size / 5)},
+		{name: "Strip", points: generateStripPoints(size, 42)},
+	}
+	orders := []struct {
+		name  string
+		order InsertionOrder
+	}{
+		{name: "SortX", order: OrderSortX},
+		{name: "Hilbert", order: OrderHilbert},
+		{name: "BRIO", order: OrderBRIO},
+	}
+
+	for _, data := range datasets {
+		for _, o := range orders {
+			name := data.name + "_" + o.name
+			b.Run(name, func(b *testing.B) {
+				// Count the walk once, outside the timed loop
+				d, err := NewDelaunay(data.points, WithInsertionOrder(o.order))
+				if err != nil {
+					b.Fatalf("Failed to initialise: %v", err)
+				}
+				var walk walkCounter
+				d.SetTracer(&walk)
+				d.Triangulate()
+
+				b.ResetTimer()
+				b.ReportAllocs()
+
+				var totalDuration time.Duration
+				for i := 0; i < b.N; i++ {
+					start := time.Now()
+					d, err = NewDelaunay(data.points, WithInsertionOrder(o.order))
+					if err != nil {
+						b.Fatalf("Failed to initialise: %v", err)
+					}
+					d.Triangulate()
+					totalDuration += time.Since(start)
+				}
+
+				avgDuration := totalDuration / time.Duration(b.N)
+				memoryPerPoint := float64(len(d.Points)*16+len(d.Triangles)*64) / float64(len(d.Points))
+				reportBenchmarkResult(b, name, size, avgDuration, len(d.Triangles), memoryPerPoint)
+				b.ReportMetric(float64(walk)/float64(size), "walk-steps/point")
+			})
+		}
+	}
+}
+
+// walkCounter is a Tracer counting the triangles walkLocate visits.
+type walkCounter int
+
+func (w *walkCounter) Trace(e TraceEvent) {
+	if e.Kind == TraceWalk {
+		*w++
+	}
+}
+
+// BenchmarkConstraintInsertion constrains a grid of square obstacles, the
+// shape of maps with thousands of obstacle edges.
+func BenchmarkConstraintInsertion(b *testing.B) {
+	for _, side := range []int{10, 30, 50} {
+		name := fmt.Sprintf("Edges_%d", 4*side*side)
+		b.Run(name, func(b *testing.B) {
+			points := obstacleGrid(side)
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			var totalDuration time.Duration
+			var d *Delaunay
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				d = runTriangulationBench(b, points)
+				b.StartTimer()
+
+				start := time.Now()
+				constrainObstacleGrid(b, d, points)
+				totalDuration += time.Since(start)
+			}
+
+			avgDuration := totalDuration / time.Duration(b.N)
+			reportBenchmarkResult(b, name, len(points), avgDuration, len(d.Triangles), 0)
+		})
+	}
+}
+
+// BenchmarkRefine refines the obstacle grid as a whole, and carved down to
+// the free space around the obstacles, where carving leaves vertices on
+// the boundary between regions.
+func BenchmarkRefine(b *testing.B) {
+	modes := []struct {
+		name  string
+		carve bool
+	}{
+		{name: "Whole", carve: false},
+		{name: "Carved", carve: true},
+	}
+	for _, side := range []int{10, 30} {
+		for _, m := range modes {
+			name := fmt.Sprintf("%s_Obstacles_%d", m.name, side*side)
+			b.Run(name, func(b *testing.B) {
+				points := obstacleGrid(side)
+
+				b.ResetTimer()
+				b.ReportAllocs()
+
+				var totalDuration time.Duration
+				var d *Delaunay
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					d = runTriangulationBench(b, points)
+					constrainObstacleGrid(b, d, points)
+					if m.carve {
+						d.ClassifyRegionsFunc(func(t Triangle) bool { return t.Depth == 0 })
+					}
+					b.StartTimer()
+
+					start := time.Now()
+					if _, err := d.Refine(RefineOptions{MinAngle: 25, MaxArea: 2}); err != nil {
+						b.Fatalf("Refine failed: %v", err)
+					}
+					totalDuration += time.Since(start)
+				}
+
+				avgDuration := totalDuration / time.Duration(b.N)
+				reportBenchmarkResult(b, name, len(d.Points), avgDuration, len(d.Triangles), 0)
+			})
+		}
+	}
+}
+
+// obstacleGrid returns the corners of a side×side grid of square obstacles,
+// four points per square.
+func obstacleGrid(side int) []Point {
+	var points []Point
+	for i := 0; i < side; i++ {
+		for j := 0; j < side; j++ {
+			x, y := float64(i)*10, float64(j)*10
+			points = append(points, Point{x, y}, Point{x + 4, y}, Point{x + 4, y + 4}, Point{x, y + 4})
+		}
+	}
+	return points
+}
+
+// constrainObstacleGrid adds the edges of every square in points.
+func constrainObstacleGrid(b *testing.B, d *Delaunay, points []Point) {
+	index := make(map[Point]int, len(d.Points))
+	for v, p := range d.Points {
+		index[p] = v
+	}
+	for k := 0; k < len(points); k += 4 {
+		for e := 0; e < 4; e++ {
+			if err := d.AddConstraint(index[points[k+e]], index[points[k+(e+1)%4]]); err != nil {
+				b.Fatalf("AddConstraint failed: %v", err)
+			}
+		}
+	}
+}
+
+// BenchmarkVertexStar visits the triangles around every vertex, through the
+// half-edge Star, through the slice incidentTriangles builds from it, and
+// through neighbourSearch, the search the refiner used before Star.
+func BenchmarkVertexStar(b *testing.B) {
+	d := runTriangulationBench(b, generateTestPoints(10000, 42))
+	walks := []struct {
+		name string
+		walk func(v int) int
+	}{
+		{name: "Star", walk: func(v int) int {
+			n := 0
+			for range d.Star(v) {
+				n++
+			}
+			return n
+		}},
+		{name: "IncidentTriangles", walk: func(v int) int {
+			return len(d.incidentTriangles(v))
+		}},
+		{name: "NeighbourSearch", walk: func(v int) int {
+			return len(neighbourSearch(d, v))
+		}},
+	}
+
+	for _, w := range walks {
+		b.Run(w.name, func(b *testing.B) {
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			var totalDuration time.Duration
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				for v := range d.Points {
+					w.walk(v)
+				}
+				totalDuration += time.Since(start)
+			}
+
+			avgDuration := totalDuration / time.Duration(b.N)
+			reportBenchmarkResult(b, w.name, len(d.Points), avgDuration, len(d.Triangles), 0)
+		})
+	}
+}
+
+// neighbourSearch is the breadth-first search over neighbour links that
+// queueAround ran before it rotated with Star, kept as BenchmarkVertexStar's
+// baseline. It returns the active triangles using v.
+func neighbourSearch(d *Delaunay, v int) []int {
+	start := d.incidentTriangle(v)
+	if start == -1 {
+		return nil
+	}
+	around := []int{start}
+	seen := map[int]bool{start: true}
+	for i := 0; i < len(around); i++ {
+		t := d.Triangles[around[i]]
+		for _, n := range [3]int32{t.T1, t.T2, t.T3} {
+			if n == -1 || seen[int(n)] {
+				continue
+			}
+			if nt := d.Triangles[n]; nt.Active && vertexSlot(nt, v) != -1 {
+				seen[int(n)] = true
+				around = append(around, int(n))
+			}
+		}
+	}
+	return around
+}
+
 func BenchmarkWalkLocate(b *testing.B) {
 	points := generateTestPoints(1000, 42)
 	d := runTriangulationBench(b, points)
@@ -0,0 +1,214 @@
+package algo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFindPathWithRadiusThroughGap(t *testing.T) {
+	// Wall from the bottom edge up to y=5 leaves a unit gap below the top edge.
+	tests := []struct {
+		name      string
+		radius    float64
+		noWall    bool
+		expectErr error
+	}{
+		{name: "Point Agent", radius: 0},
+		{name: "Wide Agent Without Wall", radius: 0.55, noWall: true},
+		{name: "Fits Gap", radius: 0.45},
+		{name: "Too Wide For Gap", radius: 0.55, expectErr: ErrNoPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, generateGrid(7))
+			if !tt.noWall {
+				if err := d.AddConstraint(vertexAt(d, Point{3, 0}), vertexAt(d, Point{3, 5})); err != nil {
+					t.Fatalf("AddConstraint failed: %v", err)
+				}
+			}
+
+			path, err := d.FindPathWithRadius(Point{1.5, 1.5}, Point{4.5, 1.5}, tt.radius)
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("Expected %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindPathWithRadius failed: %v", err)
+			}
+			assertCorridorOpen(t, d, path.Triangles)
+			if path.Radius != tt.radius {
+				t.Errorf("Expected path radius %f, got %f", tt.radius, path.Radius)
+			}
+		})
+	}
+}
+
+func TestFindPathWithRadiusStartClearance(t *testing.T) {
+	d := runTriangulation(t, generateStrip(5))
+
+	// The strip is one unit tall; its hull edges are walls.
+	if _, err := d.FindPathWithRadius(Point{0.5, 0.2}, Point{3.5, 0.5}, 0.3); !errors.Is(err, ErrStartBlocked) {
+		t.Errorf("Expected ErrStartBlocked, got %v", err)
+	}
+	if _, err := d.FindPathWithRadius(Point{0.5, 0.5}, Point{3.5, 0.9}, 0.3); !errors.Is(err, ErrGoalBlocked) {
+		t.Errorf("Expected ErrGoalBlocked, got %v", err)
+	}
+}
+
+func TestPointClearance(t *testing.T) {
+	// The wall ends at (3, 5); points just above it share no triangle with it.
+	tests := []struct {
+		name      string
+		goal      Point
+		radius    float64
+		expectErr error
+	}{
+		{name: "Clear Of Wall", goal: Point{4.5, 5.5}, radius: 0.3},
+		{name: "Near Wall Top", goal: Point{3.1, 5.1}, radius: 0.3, expectErr: ErrGoalBlocked},
+		{name: "Near Wall Side", goal: Point{3.2, 2.5}, radius: 0.3, expectErr: ErrGoalBlocked},
+		{name: "Point Agent Near Wall", goal: Point{3.1, 5.1}, radius: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, generateGrid(7))
+			if err := d.AddConstraint(vertexAt(d, Point{3, 0}), vertexAt(d, Point{3, 5})); err != nil {
+				t.Fatalf("AddConstraint failed: %v", err)
+			}
+
+			_, err := d.FindPathWithRadius(Point{1.5, 1.5}, tt.goal, tt.radius)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("Expected %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestTriangleWidth(t *testing.T) {
+	// Right triangle with a constrained hypotenuse: crossing between the two
+	// legs is limited by the distance from the right angle to the wall.
+	d := runTriangulation(t, []Point{{0, 0}, {2, 0}, {0, 2}})
+	tri := d.Triangles[0]
+	verts := [3]int32{tri.A, tri.B, tri.C}
+
+	cSlot := -1
+	for slot, v := range verts {
+		if d.Points[v] == (Point{0, 0}) {
+			cSlot = slot
+		}
+	}
+	d.Triangles[0].Constrained[cSlot] = true
+
+	entry, exit := (cSlot+1)%3, (cSlot+2)%3
+	width := d.triangleWidth(0, entry, exit, d.wallVertices(), math.Inf(1))
+	if math.Abs(width-math.Sqrt2) > 1e-9 {
+		t.Errorf("Expected width %f, got %f", math.Sqrt2, width)
+	}
+}
+
+func TestSmoothPathOffsetsCorners(t *testing.T) {
+	d := runTriangulation(t, generateGrid(7))
+	if err := d.AddConstraint(vertexAt(d, Point{3, 0}), vertexAt(d, Point{3, 5})); err != nil {
+		t.Fatalf("AddConstraint failed: %v", err)
+	}
+
+	radius := 0.3
+	path, err := d.FindPathWithRadius(Point{1.5, 1.5}, Point{5.5, 1.5}, radius)
+	if err != nil {
+		t.Fatalf("FindPathWithRadius failed: %v", err)
+	}
+	smoothed, err := d.SmoothPath(path)
+	if err != nil {
+		t.Fatalf("SmoothPath failed: %v", err)
+	}
+
+	// Corners sit exactly one radius away from the wall top they wrap, on the
+	// portals leaving it.
+	wallTop := Point{3, 5}
+	wrapped := false
+	for _, p := range smoothed.Waypoints[1 : len(smoothed.Waypoints)-1] {
+		if math.Abs(dist(p, wallTop)-radius) < 1e-9 {
+			wrapped = true
+			if p.Y < wallTop.Y {
+				t.Errorf("Corner %v should not dip below the wall top", p)
+			}
+		}
+	}
+	if !wrapped {
+		t.Errorf("Expected a corner offset from the wall top, got %v", smoothed.Waypoints)
+	}
+}
+
+func TestSmoothPathStaysInCorridor(t *testing.T) {
+	// Pushing the corners off their vertices after the funnel would cut
+	// across a neighbouring wall in the last two layouts.
+	tests := []struct {
+		name        string
+		walls       [][2]Point
+		start, goal Point
+		radius      float64
+	}{
+		{
+			name:   "Wall Top",
+			walls:  [][2]Point{{{3, 0}, {3, 5}}},
+			start:  Point{1.5, 1.5},
+			goal:   Point{5.5, 1.5},
+			radius: 0.3,
+		},
+		{
+			name:   "Ledge Below Turn",
+			walls:  [][2]Point{{{5, 3}, {5, 5}}, {{1, 3}, {3, 3}}, {{3, 4}, {6, 4}}},
+			start:  Point{1.05, 6.1},
+			goal:   Point{0.9, 0.6},
+			radius: 0.35,
+		},
+		{
+			name:   "Shelf Above Wall",
+			walls:  [][2]Point{{{3, 0}, {3, 5}}, {{2, 6}, {7, 6}}, {{7, 0}, {7, 5}}},
+			start:  Point{0.45, 7.45},
+			goal:   Point{3.5, 0.9},
+			radius: 0.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, generateGrid(9))
+			for _, w := range tt.walls {
+				if err := d.AddConstraint(vertexAt(d, w[0]), vertexAt(d, w[1])); err != nil {
+					t.Fatalf("AddConstraint failed: %v", err)
+				}
+			}
+
+			path, err := d.FindPathWithRadius(tt.start, tt.goal, tt.radius)
+			if err != nil {
+				t.Fatalf("FindPathWithRadius failed: %v", err)
+			}
+			smoothed, err := d.SmoothPath(path)
+			if err != nil {
+				t.Fatalf("SmoothPath failed: %v", err)
+			}
+
+			for i := 1; i < len(smoothed.Waypoints); i++ {
+				a, b := smoothed.Waypoints[i-1], smoothed.Waypoints[i]
+				for e := range d.Edges() {
+					if !d.isWall(e.TIdx, e.EdgeIdx) {
+						continue
+					}
+					if u, v := d.Points[d.Origin(e)], d.Points[d.Dest(e)]; segmentsIntersect(a, b, u, v) {
+						t.Errorf("Segment %v-%v clips wall %v-%v", a, b, u, v)
+					}
+				}
+			}
+			for _, p := range smoothed.Waypoints {
+				if d.locate(p) == -1 {
+					t.Errorf("Waypoint %v is outside the mesh", p)
+				}
+			}
+		})
+	}
+}
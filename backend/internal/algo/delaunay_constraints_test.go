@@ -2,6 +2,7 @@ package algo
 
 import (
 	"encoding/json"
+	"math"
 	"os"
 	"testing"
 )
@@ -87,6 +88,347 @@ func TestAddConstraint(t *testing.T) {
 	}
 }
 
+func TestAddConstraintRestoresDelaunay(t *testing.T) {
+	// Two long constraints across a random cloud force many flips; the
+	// diagonals they leave behind must be legalised again.
+	ends := []Point{{-1, 100}, {301, 120}, {-1, 200}, {301, 180}}
+	d := runTriangulation(t, append(generateTestPoints(300, 8), ends...))
+	for i := 0; i < len(ends); i += 2 {
+		if err := d.AddConstraint(vertexAt(d, ends[i]), vertexAt(d, ends[i+1])); err != nil {
+			t.Fatalf("AddConstraint failed: %v", err)
+		}
+	}
+	assertMeshConsistent(t, d)
+}
+
+func TestAddConstraintCrossing(t *testing.T) {
+	square := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	tests := []struct {
+		name      string
+		points    []Point
+		chains    [][]Point // Constrained polylines; closed if the first point repeats
+		crossings []Point   // Vertices expected where the chains cross
+	}{
+		{
+			name:      "Crossing Segments",
+			points:    square,
+			chains:    [][]Point{{{0, 0}, {10, 10}}, {{0, 10}, {10, 0}}},
+			crossings: []Point{{5, 5}},
+		},
+		{
+			name:      "Segment Through Square",
+			points:    append([]Point{{-5, 5}, {15, 5}, {5, -5}}, square...),
+			chains:    [][]Point{append(square, square[0]), {{-5, 5}, {15, 5}}},
+			crossings: []Point{{0, 5}, {10, 5}},
+		},
+		{
+			name:      "Self-Intersecting Ring",
+			points:    append(generateTestPoints(50, 3), Point{100, 100}, Point{200, 200}, Point{200, 100}, Point{100, 200}),
+			chains:    [][]Point{{{100, 100}, {200, 200}, {200, 100}, {100, 200}, {100, 100}}},
+			crossings: []Point{{150, 150}},
+		},
+		{
+			name:      "Overlapping Squares",
+			points:    append([]Point{{5, 5}, {15, 5}, {15, 15}, {5, 15}}, square...),
+			chains:    [][]Point{append(square, square[0]), {{5, 5}, {15, 5}, {15, 15}, {5, 15}, {5, 5}}},
+			crossings: []Point{{10, 5}, {5, 10}},
+		},
+		{
+			// The crossing lands within EPSILON of a vertex just off the
+			// first segment, so both segments bend to meet at that vertex.
+			name:      "Crossing Near Vertex",
+			points:    []Point{{0, 0}, {10, 0}, {4, 1e-10}, {3, -5}, {5, 5}},
+			chains:    [][]Point{{{0, 0}, {10, 0}}, {{3, -5}, {5, 5}}},
+			crossings: []Point{{4, 1e-10}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, tt.points)
+			want := 0.0
+			for _, chain := range tt.chains {
+				for i := 1; i < len(chain); i++ {
+					if err := d.AddConstraint(vertexAt(d, chain[i-1]), vertexAt(d, chain[i])); err != nil {
+						t.Fatalf("AddConstraint failed: %v", err)
+					}
+					want += dist(chain[i-1], chain[i])
+				}
+			}
+			assertMeshConsistent(t, d)
+
+			edges := constrainedEdges(d)
+			if got := totalLength(d, edges); math.Abs(got-want) > 1e-6 {
+				t.Errorf("Constrained length is %v, want %v", got, want)
+			}
+			for _, c := range tt.crossings {
+				degree := 0
+				for _, e := range edges {
+					for _, v := range e {
+						if dist(d.Points[v], c) <= 1e-9 {
+							degree++
+						}
+					}
+				}
+				// Two constraints pass through each crossing
+				if degree != 4 {
+					t.Errorf("Expected 4 constrained edges at crossing %v, got %d", c, degree)
+				}
+			}
+		})
+	}
+}
+
+func TestAddConstraintFlips(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		pieces []Point // The constrained edges the segment must end up as
+	}{
+		{
+			// Flipping whichever crossing edge is convex and rescanning can
+			// cycle here, recreating an edge it flipped before.
+			name:   "Across Random Mesh",
+			points: append(generateTestPoints(100, 5), Point{-1, 50}, Point{101, 50}),
+			pieces: []Point{{-1, 50}, {101, 50}},
+		},
+		{
+			// The segment leaves each vertex along an existing edge, so it
+			// crosses nothing and is split at every vertex it touches.
+			name:   "Along Edges Through Collinear Vertices",
+			points: generateGrid(4),
+			pieces: []Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, tt.points)
+			first, last := vertexAt(d, tt.pieces[0]), vertexAt(d, tt.pieces[len(tt.pieces)-1])
+			if err := d.AddConstraint(first, last); err != nil {
+				t.Fatalf("AddConstraint failed: %v", err)
+			}
+
+			// Once in the mesh, the segment crosses no edge
+			if edges, _, err := d.findIntersectingEdges(first, last); err != nil || len(edges) != 0 {
+				t.Errorf("Expected no crossed edges, got %d (%v)", len(edges), err)
+			}
+
+			for i := 0; i+1 < len(tt.pieces); i++ {
+				u, v := vertexAt(d, tt.pieces[i]), vertexAt(d, tt.pieces[i+1])
+				e := d.findEdge(u, v)
+				if e.TIdx == -1 || !d.Triangles[e.TIdx].Constrained[e.EdgeIdx] {
+					t.Errorf("Expected constrained edge %v-%v", tt.pieces[i], tt.pieces[i+1])
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveConstraint(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		seg    [2]Point
+	}{
+		{
+			// Delaunay picks the short diagonal; the constraint forces the long one.
+			name:   "Forced Diagonal",
+			points: []Point{{0, 0}, {4, -1}, {8, 0}, {4, 1}},
+			seg:    [2]Point{{0, 0}, {8, 0}},
+		},
+		{
+			name:   "Through Collinear Vertex",
+			points: generateGrid(4),
+			seg:    [2]Point{{0, 0}, {3, 3}},
+		},
+		{
+			name:   "Random Mesh",
+			points: append(generateTestPoints(100, 5), Point{-1, 50}, Point{101, 50}),
+			seg:    [2]Point{{-1, 50}, {101, 50}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, tt.points)
+			u, v := vertexAt(d, tt.seg[0]), vertexAt(d, tt.seg[1])
+			if err := d.AddConstraint(u, v); err != nil {
+				t.Fatalf("AddConstraint failed: %v", err)
+			}
+
+			if err := d.RemoveConstraint(u, v); err != nil {
+				t.Fatalf("RemoveConstraint failed: %v", err)
+			}
+
+			for i, tri := range d.Triangles {
+				if tri.Active && tri.Constrained != [3]bool{} {
+					t.Errorf("Triangle %d still has constrained edges %v", i, tri.Constrained)
+				}
+			}
+			assertMeshConsistent(t, d)
+		})
+	}
+}
+
+func TestRemoveCrossingConstraint(t *testing.T) {
+	// The segments cross away from any vertex, at a point that rounds off
+	// both of them, so each is bent through the inserted crossing vertex.
+	segs := [2][2]Point{{{0, 0.1}, {10, 3.3}}, {{0.3, 3.1}, {9.7, 0.2}}}
+	points := append(generateTestPoints(60, 3), segs[0][0], segs[0][1], segs[1][0], segs[1][1])
+
+	tests := []struct {
+		name    string
+		removed int
+	}{
+		{name: "Remove First", removed: 0},
+		{name: "Remove Second", removed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			removed := tt.removed
+			d := runTriangulation(t, points)
+			var ends [2][2]int
+			for i, seg := range segs {
+				ends[i] = [2]int{vertexAt(d, seg[0]), vertexAt(d, seg[1])}
+				if err := d.AddConstraint(ends[i][0], ends[i][1]); err != nil {
+					t.Fatalf("AddConstraint failed: %v", err)
+				}
+			}
+
+			if err := d.RemoveConstraint(ends[removed][0], ends[removed][1]); err != nil {
+				t.Fatalf("RemoveConstraint failed: %v", err)
+			}
+			assertMeshConsistent(t, d)
+			if _, err := d.constraintEdges(ends[removed][0], ends[removed][1]); err == nil {
+				t.Error("Expected the removed constraint to be gone")
+			}
+			kept := ends[1-removed]
+			if _, err := d.constraintEdges(kept[0], kept[1]); err != nil {
+				t.Errorf("Expected the other constraint to stay: %v", err)
+			}
+
+			if err := d.RemoveConstraint(kept[0], kept[1]); err != nil {
+				t.Fatalf("RemoveConstraint failed: %v", err)
+			}
+			for i, tri := range d.Triangles {
+				if tri.Active && tri.Constrained != [3]bool{} {
+					t.Errorf("Triangle %d still has constrained edges %v", i, tri.Constrained)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveConstraintRejects(t *testing.T) {
+	d := runTriangulation(t, generateGrid(3))
+	corner, centre, far := vertexAt(d, Point{0, 0}), vertexAt(d, Point{1, 1}), vertexAt(d, Point{2, 1})
+
+	if err := d.RemoveConstraint(corner, centre); err == nil {
+		t.Error("Expected an error for an unconstrained edge")
+	}
+	if err := d.RemoveConstraint(corner, far); err == nil {
+		t.Error("Expected an error for a segment that is not a mesh edge")
+	}
+	if err := d.RemoveConstraint(corner, len(d.Points)); err == nil {
+		t.Error("Expected an error for an out of range vertex")
+	}
+}
+
+func TestRemoveObstacle(t *testing.T) {
+	d := runTriangulation(t, generateGrid(5))
+	square := []int{
+		vertexAt(d, Point{1, 1}), vertexAt(d, Point{3, 1}),
+		vertexAt(d, Point{3, 3}), vertexAt(d, Point{1, 3}),
+	}
+	for i := range square {
+		if err := d.AddConstraint(square[i], square[(i+1)%len(square)]); err != nil {
+			t.Fatalf("AddConstraint failed: %v", err)
+		}
+	}
+
+	// A vertex is out of range, so nothing may be removed.
+	broken := append([]int{len(d.Points)}, square...)
+	if err := d.RemoveObstacle(broken); err == nil {
+		t.Fatal("Expected an error for a polygon with an out of range vertex")
+	}
+	if _, err := d.FindPath(Point{2, 2}, Point{4, 4}); err == nil {
+		t.Fatal("Obstacle should still block after a failed removal")
+	}
+
+	if err := d.RemoveObstacle(square); err != nil {
+		t.Fatalf("RemoveObstacle failed: %v", err)
+	}
+	if _, err := d.FindPath(Point{2, 2}, Point{4, 4}); err != nil {
+		t.Errorf("Expected a path once the obstacle is gone, got %v", err)
+	}
+	assertMeshConsistent(t, d)
+}
+
+func TestRemoveObstaclePartlyConstrained(t *testing.T) {
+	d := runTriangulation(t, generateGrid(5))
+	square := []int{
+		vertexAt(d, Point{1, 1}), vertexAt(d, Point{3, 1}),
+		vertexAt(d, Point{3, 3}), vertexAt(d, Point{1, 3}),
+	}
+	// The bottom side stops at (2, 1), where AddConstraint splits it, and
+	// the left side is missing, as a failed AddConstraint leaves them
+	pieces := [][2]Point{{{1, 1}, {2, 1}}, {{3, 1}, {3, 3}}, {{3, 3}, {1, 3}}}
+	for _, p := range pieces {
+		if err := d.AddConstraint(vertexAt(d, p[0]), vertexAt(d, p[1])); err != nil {
+			t.Fatalf("AddConstraint failed: %v", err)
+		}
+	}
+
+	if err := d.RemoveObstacle(square); err != nil {
+		t.Fatalf("RemoveObstacle failed: %v", err)
+	}
+	for e := range d.Edges() {
+		if d.Triangles[e.TIdx].Constrained[e.EdgeIdx] {
+			t.Errorf("Expected every piece to be removed, edge %d-%d is still constrained", d.Origin(e), d.Dest(e))
+		}
+	}
+	assertMeshConsistent(t, d)
+}
+
+func TestRemoveObstacleKeepsSharedWall(t *testing.T) {
+	d := runTriangulation(t, generateGrid(5))
+	ring := func(points ...Point) []int {
+		verts := make([]int, len(points))
+		for i, p := range points {
+			verts[i] = vertexAt(d, p)
+		}
+		for i := range verts {
+			if err := d.AddConstraint(verts[i], verts[(i+1)%len(verts)]); err != nil {
+				t.Fatalf("AddConstraint failed: %v", err)
+			}
+		}
+		return verts
+	}
+	// The wall x=2 runs the full height of the left square but only half
+	// of the right one, which is split at (2, 2)
+	left := ring(Point{0, 1}, Point{2, 1}, Point{2, 3}, Point{0, 3})
+	right := ring(Point{2, 2}, Point{4, 2}, Point{4, 4}, Point{2, 4})
+
+	if err := d.RemoveObstacle(left, right); err != nil {
+		t.Fatalf("RemoveObstacle failed: %v", err)
+	}
+	assertMeshConsistent(t, d)
+	for i := range right {
+		if _, err := d.constraintEdges(right[i], right[(i+1)%len(right)]); err != nil {
+			t.Errorf("Expected the right square to stay closed: %v", err)
+		}
+	}
+	if _, err := d.constraintEdges(vertexAt(d, Point{2, 1}), vertexAt(d, Point{2, 2})); err == nil {
+		t.Error("Expected the unshared part of the wall to be removed")
+	}
+	if _, err := d.constraintEdges(left[0], left[1]); err == nil {
+		t.Error("Expected the left square's own sides to be removed")
+	}
+}
+
 func TestDragonMap(t *testing.T) {
 	// Read Dragon Map Data from file
 	data, err := os.ReadFile("../../../dragon_map.json")
@@ -0,0 +1,133 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextCancellation(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want error
+		run  func(t *testing.T, ctx context.Context) error
+	}{
+		{
+			name: "Triangulate",
+			ctx:  cancelled,
+			want: context.Canceled,
+			run: func(t *testing.T, ctx context.Context) error {
+				d, err := NewDelaunay(generateTestPoints(300, 1))
+				if err != nil {
+					t.Fatalf("Failed to initialise: %v", err)
+				}
+				err = d.TriangulateContext(ctx)
+				if d.superRemoved {
+					t.Error("Cancelled triangulation should not have finished")
+				}
+				return err
+			},
+		},
+		{
+			name: "Triangulate Past Deadline",
+			ctx:  expired,
+			want: context.DeadlineExceeded,
+			run: func(t *testing.T, ctx context.Context) error {
+				d, err := NewDelaunay(generateTestPoints(300, 1))
+				if err != nil {
+					t.Fatalf("Failed to initialise: %v", err)
+				}
+				return d.TriangulateContext(ctx)
+			},
+		},
+		{
+			name: "Add Constraint",
+			ctx:  cancelled,
+			want: context.Canceled,
+			run: func(t *testing.T, ctx context.Context) error {
+				ends := []Point{{-1, 100}, {301, 120}}
+				d := runTriangulation(t, append(generateTestPoints(300, 8), ends...))
+				u, v := vertexAt(d, ends[0]), vertexAt(d, ends[1])
+				err := d.AddConstraintContext(ctx, u, v)
+				if e := d.findEdge(u, v); e.TIdx != -1 && d.Triangles[e.TIdx].Constrained[e.EdgeIdx] {
+					t.Error("Cancelled constraint should not have been added")
+				}
+				assertMeshConsistent(t, d)
+				return err
+			},
+		},
+		{
+			name: "Classify Regions",
+			ctx:  cancelled,
+			want: context.Canceled,
+			run: func(t *testing.T, ctx context.Context) error {
+				points, rings := nestedSquares()
+				d := runTriangulation(t, append(points, generateTestPoints(60, 3)...))
+				addRings(t, d, rings)
+				before := append([]Triangle(nil), d.Triangles...)
+				err := d.ClassifyRegionsContext(ctx)
+				if d.classified || len(d.Triangles) != len(before) {
+					t.Error("Cancelled classification should leave the mesh unchanged")
+				}
+				for i := range before {
+					if d.Triangles[i] != before[i] {
+						t.Errorf("Triangle %d changed", i)
+					}
+				}
+				return err
+			},
+		},
+		{
+			name: "Refine",
+			ctx:  cancelled,
+			want: context.Canceled,
+			run: func(t *testing.T, ctx context.Context) error {
+				d := runTriangulation(t, generateTestPoints(200, 12))
+				added, err := d.RefineContext(ctx, RefineOptions{MinAngle: 30, MaxArea: 1})
+				if added >= cancelInterval {
+					t.Errorf("Expected refinement to stop within %d points, added %d", cancelInterval, added)
+				}
+				assertMeshConsistent(t, d)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(t, tt.ctx); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestTriangulateCancelledMidway(t *testing.T) {
+	d, err := NewDelaunay(generateTestPoints(1000, 7))
+	if err != nil {
+		t.Fatalf("Failed to initialise: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	last := 0
+	err = d.TriangulateFuncContext(ctx, func(inserted, total int) {
+		last = inserted
+		if inserted == 100 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if last > 100+cancelInterval {
+		t.Errorf("Expected insertion to stop soon after 100 points, got %d", last)
+	}
+}
@@ -0,0 +1,146 @@
+package algo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDStarLiteMatchesAStar(t *testing.T) {
+	d := runTriangulation(t, generateGrid(10))
+	start, goal := Point{0.5, 0.3}, Point{8.6, 8.4}
+
+	planner, err := d.NewDStarLite(start, goal)
+	if err != nil {
+		t.Fatalf("NewDStarLite failed: %v", err)
+	}
+	path, err := planner.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+
+	reference, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	if math.Abs(path.Cost-reference.Cost) > 1e-9 {
+		t.Errorf("D* Lite cost %f differs from A* cost %f", path.Cost, reference.Cost)
+	}
+	assertCorridorOpen(t, d, path.Triangles)
+}
+
+func TestDStarLiteIncrementalReplan(t *testing.T) {
+	d := runTriangulation(t, generateGrid(30))
+	start, goal := Point{0.5, 0.3}, Point{28.6, 27.4}
+
+	planner, err := d.NewDStarLite(start, goal)
+	if err != nil {
+		t.Fatalf("NewDStarLite failed: %v", err)
+	}
+	initial, err := planner.Plan()
+	if err != nil {
+		t.Fatalf("Initial plan failed: %v", err)
+	}
+
+	// Drop an obstacle on the path a few triangles ahead of the robot.
+	blocked := initial.Triangles[3]
+	planner.SetBlocked(blocked, true)
+
+	replanned, err := planner.Plan()
+	if err != nil {
+		t.Fatalf("Replan failed: %v", err)
+	}
+	incremental := planner.Expanded()
+
+	for _, tIdx := range replanned.Triangles {
+		if tIdx == blocked {
+			t.Fatalf("Replanned path still passes through blocked triangle %d", blocked)
+		}
+	}
+
+	// Plan the same situation from scratch for comparison.
+	fresh, err := d.NewDStarLite(start, goal)
+	if err != nil {
+		t.Fatalf("NewDStarLite failed: %v", err)
+	}
+	fresh.SetBlocked(blocked, true)
+	scratch, err := fresh.Plan()
+	if err != nil {
+		t.Fatalf("Scratch plan failed: %v", err)
+	}
+
+	if math.Abs(replanned.Cost-scratch.Cost) > 1e-9 {
+		t.Errorf("Incremental cost %f differs from scratch cost %f", replanned.Cost, scratch.Cost)
+	}
+
+	t.Logf("Expanded %d nodes incrementally vs %d from scratch", incremental, fresh.Expanded())
+	if incremental*4 > fresh.Expanded() {
+		t.Errorf("Replanning expanded %d nodes, expected far fewer than %d from scratch",
+			incremental, fresh.Expanded())
+	}
+
+	// Freeing the triangle restores the original cost.
+	planner.SetBlocked(blocked, false)
+	restored, err := planner.Plan()
+	if err != nil {
+		t.Fatalf("Plan after unblocking failed: %v", err)
+	}
+	if math.Abs(restored.Cost-initial.Cost) > 1e-9 {
+		t.Errorf("Expected cost %f after unblocking, got %f", initial.Cost, restored.Cost)
+	}
+}
+
+func TestDStarLiteMoveStart(t *testing.T) {
+	d := runTriangulation(t, generateGrid(10))
+	goal := Point{8.6, 8.4}
+
+	planner, err := d.NewDStarLite(Point{0.5, 0.3}, goal)
+	if err != nil {
+		t.Fatalf("NewDStarLite failed: %v", err)
+	}
+	if _, err := planner.Plan(); err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+
+	moved := Point{4.5, 4.3}
+	if err := planner.MoveStart(moved); err != nil {
+		t.Fatalf("MoveStart failed: %v", err)
+	}
+	path, err := planner.Plan()
+	if err != nil {
+		t.Fatalf("Plan after move failed: %v", err)
+	}
+
+	reference, err := d.FindPath(moved, goal)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	if math.Abs(path.Cost-reference.Cost) > 1e-9 {
+		t.Errorf("Cost after move %f differs from A* cost %f", path.Cost, reference.Cost)
+	}
+}
+
+func TestDStarLiteNoPath(t *testing.T) {
+	d := runTriangulation(t, generateGrid(5))
+	planner, err := d.NewDStarLite(Point{0.5, 0.5}, Point{3.5, 3.5})
+	if err != nil {
+		t.Fatalf("NewDStarLite failed: %v", err)
+	}
+	initial, err := planner.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+
+	// Block every triangle touching the goal triangle to seal it off.
+	goalTri := initial.Triangles[len(initial.Triangles)-1]
+	tri := d.Triangles[goalTri]
+	for _, n := range [3]int32{tri.T1, tri.T2, tri.T3} {
+		if n != -1 {
+			planner.SetBlocked(int(n), true)
+		}
+	}
+
+	if _, err := planner.Plan(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package algo
+
+import "testing"
+
+func TestExportMesh(t *testing.T) {
+	square := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	hole := []Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}}
+
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T) *Delaunay
+		vertices int
+	}{
+		{
+			name: "Random Points",
+			setup: func(t *testing.T) *Delaunay {
+				return runTriangulation(t, generateTestPoints(200, 9))
+			},
+			vertices: 200,
+		},
+		{
+			// The outer points lose all their triangles when the mesh is carved.
+			name: "Classified With Hole",
+			setup: func(t *testing.T) *Delaunay {
+				points := append(append([]Point{{-5, 5}, {15, 5}}, square...), hole...)
+				d := runTriangulation(t, points)
+				addRings(t, d, [][]Point{square, hole})
+				d.ClassifyRegions()
+				return d
+			},
+			vertices: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.setup(t)
+			mesh := d.ExportMesh()
+
+			active := 0
+			for _, tri := range d.Triangles {
+				if tri.Active {
+					active++
+				}
+			}
+			if len(mesh.Triangles) != 3*active || len(mesh.Neighbors) != 3*active || len(mesh.Constrained) != 3*active {
+				t.Fatalf("Expected %d triangles in every buffer, got %d, %d and %d",
+					active, len(mesh.Triangles)/3, len(mesh.Neighbors)/3, len(mesh.Constrained)/3)
+			}
+			if len(mesh.Vertices) != tt.vertices || len(mesh.Sources) != tt.vertices {
+				t.Errorf("Expected %d vertices, got %d with %d sources", tt.vertices, len(mesh.Vertices), len(mesh.Sources))
+			}
+			for i, src := range mesh.Sources {
+				if d.Points[src] != mesh.Vertices[i] {
+					t.Errorf("Vertex %d is %v but its source %d is %v", i, mesh.Vertices[i], src, d.Points[src])
+				}
+			}
+
+			for i := 0; i < len(mesh.Triangles); i += 3 {
+				v := mesh.Triangles[i : i+3]
+				if orient2d(mesh.Vertices[v[0]], mesh.Vertices[v[1]], mesh.Vertices[v[2]]) <= 0 {
+					t.Errorf("Triangle %d is not counter-clockwise", i/3)
+				}
+				for slot := 0; slot < 3; slot++ {
+					n := mesh.Neighbors[i+slot]
+					if n == -1 {
+						continue
+					}
+					// The neighbour shares the edge and links back with the same flag.
+					u, w := v[(slot+1)%3], v[(slot+2)%3]
+					back := -1
+					for k := 0; k < 3; k++ {
+						nv := mesh.Triangles[3*int(n) : 3*int(n)+3]
+						if nv[(k+1)%3] == w && nv[(k+2)%3] == u {
+							back = k
+						}
+					}
+					if back == -1 || mesh.Neighbors[3*int(n)+back] != int32(i/3) {
+						t.Errorf("Triangle %d links to %d across %d-%d, which does not link back", i/3, n, u, w)
+					} else if mesh.Constrained[3*int(n)+back] != mesh.Constrained[i+slot] {
+						t.Errorf("Edge %d-%d is constrained on one side only", u, w)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTriangulateFuncSnapshots(t *testing.T) {
+	d, err := NewDelaunay(generateTestPoints(100, 5))
+	if err != nil {
+		t.Fatalf("Failed to initialise: %v", err)
+	}
+
+	calls := 0
+	var last IndexedMesh
+	d.TriangulateFunc(func(inserted, total int) {
+		calls++
+		if inserted != calls || total != 100 {
+			t.Fatalf("Call %d reported %d of %d", calls, inserted, total)
+		}
+		// Points are inserted in index order, so a snapshot only uses the first ones.
+		last = d.ExportMesh()
+		for _, src := range last.Sources {
+			if src >= inserted {
+				t.Fatalf("Snapshot after %d insertions uses vertex %d", inserted, src)
+			}
+		}
+	})
+	if calls != 100 {
+		t.Errorf("Expected 100 progress calls, got %d", calls)
+	}
+
+	// Cleanup removes exactly what the snapshots leave out.
+	if final := d.ExportMesh(); len(final.Triangles) != len(last.Triangles) || len(final.Vertices) != len(last.Vertices) {
+		t.Errorf("Last snapshot has %d triangles and %d vertices, final mesh %d and %d",
+			len(last.Triangles)/3, len(last.Vertices), len(final.Triangles)/3, len(final.Vertices))
+	}
+}
@@ -0,0 +1,128 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStringPullOpenMesh(t *testing.T) {
+	d := runTriangulation(t, generateStrip(7))
+	start, goal := Point{0.5, 0.3}, Point{5.6, 0.8}
+
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	smoothed, err := d.SmoothPath(path)
+	if err != nil {
+		t.Fatalf("SmoothPath failed: %v", err)
+	}
+
+	// Nothing blocks the way, so the taut path is the straight segment.
+	if len(smoothed.Waypoints) != 2 {
+		t.Errorf("Expected straight segment, got %v", smoothed.Waypoints)
+	}
+	if math.Abs(smoothed.Cost-dist(start, goal)) > 1e-9 {
+		t.Errorf("Expected cost %f, got %f", dist(start, goal), smoothed.Cost)
+	}
+	if smoothed.Cost > path.Cost+1e-9 {
+		t.Errorf("Smoothed cost %f is longer than raw cost %f", smoothed.Cost, path.Cost)
+	}
+}
+
+func TestStringPullAroundWall(t *testing.T) {
+	d := runTriangulation(t, generateGrid(5))
+	if err := d.AddConstraint(vertexAt(d, Point{2, 0}), vertexAt(d, Point{2, 3})); err != nil {
+		t.Fatalf("AddConstraint failed: %v", err)
+	}
+
+	start, goal := Point{0.5, 0.5}, Point{3.5, 0.5}
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	smoothed, err := d.SmoothPath(path)
+	if err != nil {
+		t.Fatalf("SmoothPath failed: %v", err)
+	}
+
+	// Every corner must be a mesh vertex, and the path has to wrap the top of the wall.
+	wrapsWall := false
+	for _, p := range smoothed.Waypoints[1 : len(smoothed.Waypoints)-1] {
+		if vertexAt(d, p) == -1 {
+			t.Errorf("Corner %v is not a mesh vertex", p)
+		}
+		if p == (Point{2, 3}) {
+			wrapsWall = true
+		}
+	}
+	if !wrapsWall {
+		t.Errorf("Expected a corner at the top of the wall, got %v", smoothed.Waypoints)
+	}
+
+	for i := 1; i < len(smoothed.Waypoints); i++ {
+		if segmentsIntersect(smoothed.Waypoints[i-1], smoothed.Waypoints[i], Point{2, 0}, Point{2, 3}) {
+			t.Errorf("Segment %v-%v crosses the wall", smoothed.Waypoints[i-1], smoothed.Waypoints[i])
+		}
+	}
+	if smoothed.Cost > path.Cost+1e-9 {
+		t.Errorf("Smoothed cost %f is longer than raw cost %f", smoothed.Cost, path.Cost)
+	}
+}
+
+func TestStringPullFromVertex(t *testing.T) {
+	d := runTriangulation(t, generateStrip(7))
+
+	// Start on a mesh vertex: the first portals touch the start itself.
+	start, goal := Point{1, 0}, Point{5.6, 0.8}
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	smoothed, err := d.SmoothPath(path)
+	if err != nil {
+		t.Fatalf("SmoothPath failed: %v", err)
+	}
+	if len(smoothed.Waypoints) != 2 {
+		t.Errorf("Expected straight segment without repeated corners, got %v", smoothed.Waypoints)
+	}
+}
+
+func TestStringPullRejectsBrokenCorridor(t *testing.T) {
+	d := runTriangulation(t, generateGrid(5))
+
+	// First and last triangles of the mesh are not neighbours.
+	if _, err := d.StringPull(Point{0.5, 0.5}, Point{3.5, 3.5}, []int{0, len(d.Triangles) - 1}); err == nil {
+		t.Error("Expected error for corridor of non-adjacent triangles")
+	}
+}
+
+func TestSmoothPathRejectsShortPath(t *testing.T) {
+	d := runTriangulation(t, generateStrip(4))
+
+	tests := []struct {
+		name string
+		path *Path
+	}{
+		{name: "Nil Path", path: nil},
+		{name: "No Waypoints", path: &Path{Triangles: []int{0}}},
+		{name: "Single Waypoint", path: &Path{Waypoints: []Point{{0.5, 0.5}}, Triangles: []int{0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := d.SmoothPath(tt.path); err == nil {
+				t.Error("Expected error for a path without a start and a goal")
+			}
+		})
+	}
+}
+
+// generateStrip returns two rows of points at y=0 and y=1. The triangulation
+// is a single strip, so every corridor along it contains the straight line.
+func generateStrip(length int) []Point {
+	points := make([]Point, 0, 2*length)
+	for x := 0; x < length; x++ {
+		points = append(points, Point{float64(x), 0}, Point{float64(x), 1})
+	}
+	return points
+}
@@ -0,0 +1,232 @@
+package algo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHalfEdges(t *testing.T) {
+	square := []Point{{10, 10}, {40, 10}, {40, 40}, {10, 40}}
+	addSquare := func(d *Delaunay) {
+		for i := range square {
+			u, v := vertexAt(d, square[i]), vertexAt(d, square[(i+1)%len(square)])
+			if err := d.AddConstraint(u, v); err != nil {
+				t.Fatalf("AddConstraint failed: %v", err)
+			}
+		}
+	}
+	points := append(generateTestPoints(300, 8), square...)
+	addRings := func(d *Delaunay, rings ...[]Point) {
+		for _, ring := range rings {
+			vs := make([]int, len(ring))
+			for i, p := range ring {
+				v, err := d.InsertPoint(p)
+				if err != nil {
+					t.Fatalf("InsertPoint failed: %v", err)
+				}
+				vs[i] = v
+			}
+			for i := range vs {
+				if err := d.AddConstraint(vs[i], vs[(i+1)%len(vs)]); err != nil {
+					t.Fatalf("AddConstraint failed: %v", err)
+				}
+			}
+		}
+	}
+	// Two squares meeting at (25, 25), whose fan carving splits in two
+	addTouching := func(d *Delaunay) {
+		addRings(d, []Point{{12, 12}, {25, 12}, {25, 25}, {12, 25}}, []Point{{25, 25}, {38, 25}, {38, 38}, {25, 38}})
+		d.ClassifyRegions()
+	}
+
+	tests := []struct {
+		name string
+		edit func(d *Delaunay)
+	}{
+		{name: "Triangulated", edit: func(d *Delaunay) {}},
+		{name: "Constrained", edit: addSquare},
+		{
+			name: "Inserted Outside Hull",
+			edit: func(d *Delaunay) {
+				for _, p := range []Point{{-20, -20}, {200, 50}, {50, 200}} {
+					if _, err := d.InsertPoint(p); err != nil {
+						t.Fatalf("InsertPoint failed: %v", err)
+					}
+				}
+			},
+		},
+		{
+			name: "Removed",
+			edit: func(d *Delaunay) {
+				r := rand.New(rand.NewSource(2))
+				for i := 0; i < 60; i++ {
+					d.RemovePoint(r.Intn(len(d.Points))) // Refusals leave the mesh as it was
+				}
+			},
+		},
+		{
+			name: "Classified",
+			edit: func(d *Delaunay) {
+				addSquare(d)
+				d.ClassifyRegions()
+			},
+		},
+		{name: "Classified At A Shared Corner", edit: addTouching},
+		{
+			name: "Removed Around Holes",
+			edit: func(d *Delaunay) {
+				var holes [][]Point
+				for _, c := range []Point{{150, 5}, {295, 150}, {150, 295}, {5, 150}, {20, 20}, {280, 280}} {
+					holes = append(holes, []Point{{c.X - 3, c.Y - 3}, {c.X + 3, c.Y - 3}, {c.X + 3, c.Y + 3}, {c.X - 3, c.Y + 3}})
+				}
+				addRings(d, holes...)
+				d.ClassifyRegionsFunc(func(t Triangle) bool { return t.Depth == 0 })
+				r := rand.New(rand.NewSource(2))
+				for i := 0; i < 300; i++ {
+					d.RemovePoint(r.Intn(len(d.Points))) // Refusals leave the mesh as it was
+				}
+			},
+		},
+		{
+			name: "Refined At A Shared Corner",
+			edit: func(d *Delaunay) {
+				addTouching(d)
+				if _, err := d.Refine(RefineOptions{MinAngle: 25}); err != nil {
+					t.Fatalf("Refine failed: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, points)
+			tt.edit(d)
+			assertMeshConsistent(t, d)
+			assertHalfEdges(t, d)
+			assertEdges(t, d)
+			assertStars(t, d)
+		})
+	}
+}
+
+func TestHalfEdgeIteratorsStop(t *testing.T) {
+	d := runTriangulation(t, generateTestPoints(50, 1))
+
+	count := 0
+	for range d.Edges() {
+		if count++; count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("Expected Edges to stop after 3 edges, got %d", count)
+	}
+
+	count = 0
+	for range d.Star(0) {
+		if count++; count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("Expected Star to stop after 2 edges, got %d", count)
+	}
+}
+
+// assertHalfEdges checks Next, Prev and Twin against the triangles: each
+// triangle is a cycle of three half-edges, and twins run between the same
+// vertices the other way.
+func assertHalfEdges(t *testing.T, d *Delaunay) {
+	t.Helper()
+	for i, tri := range d.Triangles {
+		if !tri.Active {
+			continue
+		}
+		for slot := 0; slot < 3; slot++ {
+			e := EdgeRef{TIdx: i, EdgeIdx: slot}
+			if e.Next().Next().Next() != e || e.Next().Prev() != e {
+				t.Fatalf("Half-edge %v does not cycle within its triangle", e)
+			}
+			if d.Origin(e.Next()) != d.Dest(e) || d.Apex(e) != d.Dest(e.Next()) {
+				t.Fatalf("Half-edge %v does not lead into the next", e)
+			}
+			twin := d.Twin(e)
+			if twin.TIdx == -1 {
+				continue
+			}
+			if d.Twin(twin) != e {
+				t.Errorf("Twin of twin of %v is %v", e, d.Twin(twin))
+			}
+			if d.Origin(twin) != d.Dest(e) || d.Dest(twin) != d.Origin(e) {
+				t.Errorf("Twin %v of %v does not reverse it", twin, e)
+			}
+		}
+	}
+}
+
+// assertEdges checks Edges yields every edge of the mesh exactly once.
+func assertEdges(t *testing.T, d *Delaunay) {
+	t.Helper()
+	halfEdges, hull := 0, 0
+	for _, tri := range d.Triangles {
+		if !tri.Active {
+			continue
+		}
+		for _, n := range [3]int32{tri.T1, tri.T2, tri.T3} {
+			halfEdges++
+			if n == -1 {
+				hull++
+			}
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	for e := range d.Edges() {
+		u, v := d.Origin(e), d.Dest(e)
+		key := [2]int{min(u, v), max(u, v)}
+		if seen[key] {
+			t.Errorf("Edge %d-%d yielded twice", u, v)
+		}
+		seen[key] = true
+	}
+	// Interior edges have two half-edges, hull edges one
+	if want := (halfEdges + hull) / 2; len(seen) != want {
+		t.Errorf("Expected %d edges, got %d", want, len(seen))
+	}
+}
+
+// assertStars checks Star yields one half-edge leaving each vertex per
+// triangle around it, rotating counter-clockwise unless carving split its fan.
+func assertStars(t *testing.T, d *Delaunay) {
+	t.Helper()
+	around := make(map[int]int)
+	for _, tri := range d.Triangles {
+		if tri.Active {
+			around[int(tri.A)]++
+			around[int(tri.B)]++
+			around[int(tri.C)]++
+		}
+	}
+
+	for v := range d.Points {
+		seen := make(map[int]bool)
+		var prev EdgeRef
+		for e := range d.Star(v) {
+			if d.Origin(e) != v {
+				t.Fatalf("Star of %d yielded %v leaving %d", v, e, d.Origin(e))
+			}
+			if seen[e.TIdx] {
+				t.Fatalf("Star of %d yielded triangle %d twice", v, e.TIdx)
+			}
+			if len(seen) > 0 && !d.splitFans[v] && d.Twin(prev.Prev()) != e {
+				t.Errorf("Star of %d is not in CCW order at %v", v, e)
+			}
+			seen[e.TIdx] = true
+			prev = e
+		}
+		if len(seen) != around[v] {
+			t.Errorf("Expected %d triangles around vertex %d, got %d", around[v], v, len(seen))
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package algo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestVertexIndex(t *testing.T) {
+	square := []Point{{10, 10}, {40, 10}, {40, 40}, {10, 40}}
+	addSquare := func(d *Delaunay) {
+		for i := range square {
+			u, v := vertexAt(d, square[i]), vertexAt(d, square[(i+1)%len(square)])
+			if err := d.AddConstraint(u, v); err != nil {
+				t.Fatalf("AddConstraint failed: %v", err)
+			}
+		}
+	}
+	points := append(generateTestPoints(300, 5), square...)
+
+	tests := []struct {
+		name string
+		edit func(d *Delaunay)
+	}{
+		{name: "Triangulated", edit: func(d *Delaunay) {}},
+		{name: "Constrained", edit: addSquare},
+		{
+			name: "Crossing Constraints",
+			edit: func(d *Delaunay) {
+				addSquare(d)
+				for _, p := range []Point{{0, 25}, {50, 25}} {
+					if _, err := d.InsertPoint(p); err != nil {
+						t.Fatalf("InsertPoint failed: %v", err)
+					}
+				}
+				if err := d.AddConstraint(vertexAt(d, Point{0, 25}), vertexAt(d, Point{50, 25})); err != nil {
+					t.Fatalf("AddConstraint failed: %v", err)
+				}
+			},
+		},
+		{
+			name: "Inserted Outside Hull",
+			edit: func(d *Delaunay) {
+				for _, p := range []Point{{-20, -20}, {200, 50}, {50, 200}} {
+					if _, err := d.InsertPoint(p); err != nil {
+						t.Fatalf("InsertPoint failed: %v", err)
+					}
+				}
+			},
+		},
+		{
+			name: "Removed",
+			edit: func(d *Delaunay) {
+				r := rand.New(rand.NewSource(9))
+				for i := 0; i < 60; i++ {
+					d.RemovePoint(r.Intn(len(d.Points))) // Refusals leave the mesh as it was
+				}
+			},
+		},
+		{
+			name: "Constraint Removed",
+			edit: func(d *Delaunay) {
+				addSquare(d)
+				if err := d.RemoveConstraint(vertexAt(d, square[0]), vertexAt(d, square[1])); err != nil {
+					t.Fatalf("RemoveConstraint failed: %v", err)
+				}
+			},
+		},
+		{
+			name: "Classified And Refined",
+			edit: func(d *Delaunay) {
+				addSquare(d)
+				d.ClassifyRegions()
+				if _, err := d.Refine(RefineOptions{MinAngle: 25}); err != nil {
+					t.Fatalf("Refine failed: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, points)
+			tt.edit(d)
+			assertMeshConsistent(t, d)
+			assertVertexIndex(t, d)
+			assertVertexIndex(t, d.Clone())
+		})
+	}
+}
+
+// assertVertexIndex checks every vertex maps to a triangle using it, or to
+// -1 exactly when no triangle does.
+func assertVertexIndex(t *testing.T, d *Delaunay) {
+	t.Helper()
+	used := make(map[int]bool)
+	for _, tri := range d.Triangles {
+		if tri.Active {
+			used[int(tri.A)], used[int(tri.B)], used[int(tri.C)] = true, true, true
+		}
+	}
+	for v := range d.Points {
+		got := d.incidentTriangle(v)
+		switch {
+		case used[v] && got == -1:
+			t.Errorf("Vertex %d is in the mesh but not indexed", v)
+		case !used[v] && got != -1:
+			t.Errorf("Vertex %d is not in the mesh but indexed to triangle %d", v, got)
+		}
+	}
+}
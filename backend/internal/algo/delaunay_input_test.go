@@ -0,0 +1,85 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInputVertex(t *testing.T) {
+	points := generateTestPoints(500, 6)
+	// Copies of earlier points, exact and within EPSILON
+	points = append(points, points[3], Point{points[7].X + EPSILON/2, points[7].Y - EPSILON/2})
+	merged := map[int]int{500: 3, 501: 7}
+
+	orders := []struct {
+		name  string
+		order InsertionOrder
+	}{
+		{name: "SortX", order: OrderSortX},
+		{name: "Hilbert", order: OrderHilbert},
+		{name: "BRIO", order: OrderBRIO},
+	}
+
+	for _, o := range orders {
+		t.Run(o.name, func(t *testing.T) {
+			d, err := NewDelaunay(points, WithInsertionOrder(o.order))
+			if err != nil {
+				t.Fatalf("Failed to initialise: %v", err)
+			}
+			d.Triangulate()
+
+			seen := make(map[int]int)
+			for i, p := range points {
+				v := d.InputVertex(i)
+				if v < 0 || v >= len(d.Points) {
+					t.Fatalf("Input %d maps to vertex %d of %d", i, v, len(d.Points))
+				}
+				if q := d.Points[v]; math.Abs(q.X-p.X) > EPSILON || math.Abs(q.Y-p.Y) > EPSILON {
+					t.Errorf("Input %d at %v maps to vertex %d at %v", i, p, v, q)
+				}
+				if first, ok := merged[i]; ok {
+					if want := d.InputVertex(first); v != want {
+						t.Errorf("Merged input %d maps to vertex %d, want %d", i, v, want)
+					}
+					continue
+				}
+				if other, dup := seen[v]; dup {
+					t.Errorf("Inputs %d and %d both map to vertex %d", other, i, v)
+				}
+				seen[v] = i
+			}
+
+			for _, i := range []int{-1, len(points)} {
+				if v := d.InputVertex(i); v != -1 {
+					t.Errorf("Expected -1 for input %d, got %d", i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestAddInputConstraint(t *testing.T) {
+	// A square whose second corner is repeated just off itself
+	square := []Point{{10, 10}, {40, 10}, {40 + EPSILON/2, 10}, {40, 40}, {10, 40}}
+	points := append(square, generateTestPoints(200, 12)...)
+	d := runTriangulation(t, points)
+
+	for i := range square {
+		if err := d.AddInputConstraint(i, (i+1)%len(square)); err != nil {
+			t.Fatalf("AddInputConstraint failed: %v", err)
+		}
+	}
+	assertMeshConsistent(t, d)
+
+	corners := []int{0, 1, 3, 4}
+	for i := range corners {
+		u, v := d.InputVertex(corners[i]), d.InputVertex(corners[(i+1)%len(corners)])
+		if _, err := d.constraintEdges(u, v); err != nil {
+			t.Errorf("Expected side %d-%d to be constrained: %v", u, v, err)
+		}
+	}
+
+	if err := d.AddInputConstraint(0, len(points)); err == nil {
+		t.Error("Expected an error for an input index out of range")
+	}
+}
@@ -0,0 +1,168 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestInsertPoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		point     Point
+		expectAdd int // Triangles added
+	}{
+		{name: "Interior", point: Point{3, 2}, expectAdd: 2},
+		{name: "On Interior Edge", point: Point{5, 5}, expectAdd: 2},
+		{name: "On Hull Edge", point: Point{5, 0}, expectAdd: 1},
+		{name: "Outside One Edge", point: Point{5, -3}, expectAdd: 1},
+		{name: "Outside Corner", point: Point{15, -5}, expectAdd: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}})
+			before := d.TriangleCount()
+
+			idx, err := d.InsertPoint(tt.point)
+			if err != nil {
+				t.Fatalf("InsertPoint failed: %v", err)
+			}
+			if d.Points[idx] != tt.point {
+				t.Errorf("Returned index %d holds %v, want %v", idx, d.Points[idx], tt.point)
+			}
+			if d.incidentTriangle(idx) == -1 {
+				t.Errorf("Inserted vertex %d is not in the mesh", idx)
+			}
+			if got := d.TriangleCount() - before; got != tt.expectAdd {
+				t.Errorf("Expected %d new triangles, got %d", tt.expectAdd, got)
+			}
+			assertMeshConsistent(t, d)
+		})
+	}
+}
+
+func TestInsertPointMatchesRetriangulation(t *testing.T) {
+	points := generateTestPoints(100, 3)
+	d := runTriangulation(t, points)
+
+	// Spread extra points over a wider box so many land outside the hull.
+	r := rand.New(rand.NewSource(11))
+	all := append([]Point(nil), points...)
+	for i := 0; i < 100; i++ {
+		p := Point{r.Float64()*300 - 100, r.Float64()*300 - 100}
+		if _, err := d.InsertPoint(p); err != nil {
+			t.Fatalf("InsertPoint(%v) failed: %v", p, err)
+		}
+		all = append(all, p)
+	}
+	assertMeshConsistent(t, d)
+
+	// Locally Delaunay with a convex boundary means globally Delaunay.
+	for i, tri := range d.Triangles {
+		verts := [3]int32{tri.A, tri.B, tri.C}
+		for slot, n := range [3]int32{tri.T1, tri.T2, tri.T3} {
+			if !tri.Active || n != -1 {
+				continue
+			}
+			a, b := d.Points[verts[(slot+1)%3]], d.Points[verts[(slot+2)%3]]
+			for _, p := range all {
+				if d.orient2d(a, b, p) < -EPSILON {
+					t.Errorf("Hull edge of triangle %d has %v outside it", i, p)
+				}
+			}
+		}
+	}
+}
+
+func TestInsertPointBeforeTriangulate(t *testing.T) {
+	d, err := NewDelaunay([]Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}})
+	if err != nil {
+		t.Fatalf("Failed to initialise: %v", err)
+	}
+	idx, err := d.InsertPoint(Point{5, 5})
+	if err != nil {
+		t.Fatalf("InsertPoint failed: %v", err)
+	}
+	d.Triangulate()
+
+	if d.incidentTriangle(idx) == -1 {
+		t.Error("Point inserted before Triangulate is missing from the mesh")
+	}
+	if got := d.TriangleCount(); got != 4 {
+		t.Errorf("Expected 4 triangles, got %d", got)
+	}
+	assertMeshConsistent(t, d)
+}
+
+// A point repeating an input point that Triangulate has not inserted yet
+// must return that point's vertex rather than adding it twice.
+func TestInsertPointBeforeTriangulateDuplicate(t *testing.T) {
+	d, err := NewDelaunay([]Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {5, 5}})
+	if err != nil {
+		t.Fatalf("Failed to initialise: %v", err)
+	}
+	want := vertexAt(d, Point{5, 5})
+	idx, err := d.InsertPoint(Point{5, 5})
+	if err != nil {
+		t.Fatalf("InsertPoint failed: %v", err)
+	}
+	if idx != want {
+		t.Errorf("Expected existing vertex %d, got %d", want, idx)
+	}
+	d.Triangulate()
+
+	for _, v := range d.Validate() {
+		t.Errorf("Violation after Triangulate: %v", v)
+	}
+	if got := d.TriangleCount(); got != 4 {
+		t.Errorf("Expected 4 triangles, got %d", got)
+	}
+	assertMeshConsistent(t, d)
+}
+
+func TestInsertPointSplitsConstraint(t *testing.T) {
+	d := runTriangulation(t, generateGrid(3))
+	u, v := vertexAt(d, Point{0, 1}), vertexAt(d, Point{1, 1})
+	if err := d.AddConstraint(u, v); err != nil {
+		t.Fatalf("AddConstraint failed: %v", err)
+	}
+
+	idx, err := d.InsertPoint(Point{0.5, 1})
+	if err != nil {
+		t.Fatalf("InsertPoint failed: %v", err)
+	}
+	assertMeshConsistent(t, d)
+
+	for _, end := range []int{u, v} {
+		found := false
+		for _, tri := range d.Triangles {
+			if slot := edgeSlot(tri, int32(idx), int32(end)); tri.Active && slot != -1 {
+				found = true
+				if !tri.Constrained[slot] {
+					t.Errorf("Half %d-%d of the split constraint lost its flag", idx, end)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Edge %d-%d missing after split", idx, end)
+		}
+	}
+}
+
+func TestInsertPointRejects(t *testing.T) {
+	d := runTriangulation(t, []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}})
+
+	idx, err := d.InsertPoint(Point{10, 10})
+	if err != nil || d.Points[idx] != (Point{10, 10}) {
+		t.Errorf("Duplicate should return the existing vertex, got %d, %v", idx, err)
+	}
+	if _, err := d.InsertPoint(Point{math.NaN(), 0}); err == nil {
+		t.Error("Expected an error for NaN input")
+	}
+
+	d.ClassifyRegions()
+	if _, err := d.InsertPoint(Point{20, 20}); err == nil {
+		t.Error("Expected an error outside a classified mesh")
+	}
+}
@@ -0,0 +1,105 @@
+package algo
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestInsertionOrders(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+	}{
+		{name: "Random", points: generateTestPoints(2000, 42)},
+		{name: "Clustered", points: generateDenseClusters(400)},
+		{name: "Strip", points: generateStripPoints(2000, 42)},
+		{name: "Grid", points: generateGrid(20)},
+	}
+	orders := []struct {
+		name  string
+		order InsertionOrder
+	}{
+		{name: "Hilbert", order: OrderHilbert},
+		{name: "BRIO", order: OrderBRIO},
+	}
+
+	for _, tt := range tests {
+		want := runTriangulation(t, tt.points).TriangleCount()
+		for _, o := range orders {
+			t.Run(tt.name+" "+o.name, func(t *testing.T) {
+				d, err := NewDelaunay(tt.points, WithInsertionOrder(o.order))
+				if err != nil {
+					t.Fatalf("Failed to initialise: %v", err)
+				}
+				d.Triangulate()
+
+				assertMeshConsistent(t, d)
+				// Every order triangulates the same points, so the triangle
+				// count is fixed by the hull (Euler's formula)
+				if got := d.TriangleCount(); got != want {
+					t.Errorf("Expected %d triangles, got %d", want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestBRIOSeed(t *testing.T) {
+	points := generateTestPoints(500, 3)
+	build := func(seed int64) []Point {
+		d, err := NewDelaunay(points, WithInsertionOrder(OrderBRIO), WithSeed(seed))
+		if err != nil {
+			t.Fatalf("Failed to initialise: %v", err)
+		}
+		return d.Points
+	}
+
+	if !slices.Equal(build(7), build(7)) {
+		t.Error("Expected the same seed to give the same insertion order")
+	}
+	if slices.Equal(build(7), build(8)) {
+		t.Error("Expected different seeds to give different insertion orders")
+	}
+}
+
+func TestHilbertIndex(t *testing.T) {
+	// The first 64 cells of the curve fill the 8x8 corner block, each
+	// sharing a side with the one before
+	type cell struct{ x, y uint32 }
+	var cells []cell
+	for x := uint32(0); x < 8; x++ {
+		for y := uint32(0); y < 8; y++ {
+			cells = append(cells, cell{x, y})
+		}
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		return hilbertIndex(cells[i].x, cells[i].y) < hilbertIndex(cells[j].x, cells[j].y)
+	})
+
+	for i, c := range cells {
+		if got := hilbertIndex(c.x, c.y); got != uint64(i) {
+			t.Fatalf("Expected index %d for cell %v, got %d", i, c, got)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := cells[i-1]
+		dx, dy := int(c.x)-int(prev.x), int(c.y)-int(prev.y)
+		if dx*dx+dy*dy != 1 {
+			t.Errorf("Cells %v and %v are consecutive but not adjacent", prev, c)
+		}
+	}
+}
+
+// generateStripPoints scatters points over a tall, narrow strip, the worst
+// case for sorting by X.
+func generateStripPoints(size int, seed int64) []Point {
+	r := rand.New(rand.NewSource(seed))
+	points := make([]Point, size)
+	for i := range points {
+		points[i] = Point{r.Float64() * 10, r.Float64() * 10000}
+	}
+	return points
+}
@@ -0,0 +1,160 @@
+package algo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFindPathOpenGrid(t *testing.T) {
+	d := runTriangulation(t, generateGrid(5))
+
+	start, goal := Point{0.5, 0.5}, Point{3.5, 3.2}
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+
+	if path.Waypoints[0] != start || path.Waypoints[len(path.Waypoints)-1] != goal {
+		t.Errorf("Path should run from start to goal, got %v", path.Waypoints)
+	}
+	if len(path.Waypoints) != len(path.Triangles)+2 {
+		t.Errorf("Expected one waypoint per corridor triangle plus start/goal, got %d waypoints for %d triangles",
+			len(path.Waypoints), len(path.Triangles))
+	}
+	if path.Cost < dist(start, goal)-EPSILON {
+		t.Errorf("Path cost %f is shorter than the straight line %f", path.Cost, dist(start, goal))
+	}
+
+	length := 0.0
+	for i := 1; i < len(path.Waypoints); i++ {
+		length += dist(path.Waypoints[i-1], path.Waypoints[i])
+	}
+	if math.Abs(length-path.Cost) > 1e-9 {
+		t.Errorf("Cost %f does not match waypoint length %f", path.Cost, length)
+	}
+
+	assertCorridorOpen(t, d, path.Triangles)
+}
+
+func TestFindPathSameTriangle(t *testing.T) {
+	d := runTriangulation(t, []Point{{0, 0}, {10, 0}, {5, 10}})
+
+	path, err := d.FindPath(Point{4, 2}, Point{6, 2})
+	if err != nil {
+		t.Fatalf("FindPath failed: %v", err)
+	}
+	if len(path.Waypoints) != 2 || len(path.Triangles) != 1 {
+		t.Errorf("Expected direct segment inside one triangle, got %d waypoints, %d triangles",
+			len(path.Waypoints), len(path.Triangles))
+	}
+	if math.Abs(path.Cost-2) > EPSILON {
+		t.Errorf("Expected cost 2, got %f", path.Cost)
+	}
+}
+
+func TestFindPathRespectsConstraints(t *testing.T) {
+	tests := []struct {
+		name      string
+		wall      [2]Point
+		expectErr error
+	}{
+		{
+			name: "Wall With Gap",
+			wall: [2]Point{{2, 0}, {2, 3}},
+		},
+		{
+			name:      "Wall Across Mesh",
+			wall:      [2]Point{{2, 0}, {2, 4}},
+			expectErr: ErrNoPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, generateGrid(5))
+			if err := d.AddConstraint(vertexAt(d, tt.wall[0]), vertexAt(d, tt.wall[1])); err != nil {
+				t.Fatalf("AddConstraint failed: %v", err)
+			}
+
+			path, err := d.FindPath(Point{0.5, 0.5}, Point{3.5, 0.5})
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("Expected %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindPath failed: %v", err)
+			}
+
+			assertCorridorOpen(t, d, path.Triangles)
+
+			// The only way round is over the top of the wall.
+			reachedGap := false
+			for _, tIdx := range path.Triangles {
+				tri := d.Triangles[tIdx]
+				for _, v := range []int32{tri.A, tri.B, tri.C} {
+					if d.Points[v].Y >= 3 {
+						reachedGap = true
+					}
+				}
+			}
+			if !reachedGap {
+				t.Error("Path should detour through the gap above the wall")
+			}
+		})
+	}
+}
+
+func TestFindPathOutsideMesh(t *testing.T) {
+	d := runTriangulation(t, generateGrid(3))
+
+	if _, err := d.FindPath(Point{-5, -5}, Point{1, 1}); !errors.Is(err, ErrStartBlocked) {
+		t.Errorf("Expected ErrStartBlocked, got %v", err)
+	}
+	if _, err := d.FindPath(Point{1, 1}, Point{50, 1}); !errors.Is(err, ErrGoalBlocked) {
+		t.Errorf("Expected ErrGoalBlocked, got %v", err)
+	}
+}
+
+// assertCorridorOpen checks consecutive corridor triangles share an unconstrained edge.
+func assertCorridorOpen(t *testing.T, d *Delaunay, corridor []int) {
+	t.Helper()
+	for i := 1; i < len(corridor); i++ {
+		a := d.Triangles[corridor[i-1]]
+		shared := false
+		for slot, n := range [3]int32{a.T1, a.T2, a.T3} {
+			if int(n) == corridor[i] {
+				shared = true
+				if a.Constrained[slot] {
+					t.Errorf("Corridor crosses constrained edge between %d and %d", corridor[i-1], corridor[i])
+				}
+			}
+		}
+		if !shared {
+			t.Errorf("Corridor triangles %d and %d are not neighbours", corridor[i-1], corridor[i])
+		}
+	}
+}
+
+// generateGrid returns size x size points at integer coordinates.
+func generateGrid(size int) []Point {
+	points := make([]Point, 0, size*size)
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			points = append(points, Point{float64(x), float64(y)})
+		}
+	}
+	return points
+}
+
+// vertexAt returns the mesh index of the vertex at exactly p, or -1.
+func vertexAt(d *Delaunay, p Point) int {
+	for i, dp := range d.Points {
+		if dp == p {
+			return i
+		}
+	}
+	return -1
+}
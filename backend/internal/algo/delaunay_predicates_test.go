@@ -0,0 +1,174 @@
+package algo
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func FuzzOrient2d(f *testing.F) {
+	// Near-collinear seeds: c is a rounded point on line ab.
+	f.Add(0.0, 0.0, 1.0, 1.0, 0.5, 0.5+1e-17)
+	f.Add(0.5, 0.5, 12.0, 12.0, 24.0, 24.0)
+	f.Add(1e15, 1e15, 1e15+1, 1e15+1, 1e15+0.5, 1e15+0.5)
+	f.Add(1e-12, 2e-12, 3e-12, 6e-12, 2e-12, 4e-12+1e-28)
+
+	f.Fuzz(func(t *testing.T, ax, ay, bx, by, cx, cy float64) {
+		a, b, c := Point{ax, ay}, Point{bx, by}, Point{cx, cy}
+		if !finite(ax, ay, bx, by, cx, cy) || !bounded(1e100, ax, ay, bx, by, cx, cy) {
+			t.Skip()
+		}
+
+		want := ratOrient(a, b, c).Sign()
+		if got := sign(orient2d(a, b, c)); got != want {
+			t.Errorf("orient2d(%v, %v, %v) sign %d, want %d", a, b, c, got, want)
+		}
+	})
+}
+
+func FuzzInCircle(f *testing.F) {
+	// Square corners are exactly cocircular; the rest sit within rounding of it.
+	f.Add(0.0, 0.0, 1.0, 0.0, 1.0, 1.0, 0.0, 1.0)
+	f.Add(1.0, 0.0, math.Cos(2), math.Sin(2), math.Cos(4), math.Sin(4), math.Cos(1), math.Sin(1))
+	f.Add(1e6, 1e6, 1e6+1, 1e6, 1e6+1, 1e6+1, 1e6, 1e6+1+1e-10)
+	f.Add(0.0, 0.0, 1e-7, 0.0, 1e-7, 1e-7, 0.0, 1e-7)
+
+	f.Fuzz(func(t *testing.T, ax, ay, bx, by, cx, cy, px, py float64) {
+		a, b, c, p := Point{ax, ay}, Point{bx, by}, Point{cx, cy}, Point{px, py}
+		if !finite(ax, ay, bx, by, cx, cy, px, py) || !bounded(1e60, ax, ay, bx, by, cx, cy, px, py) {
+			t.Skip()
+		}
+
+		want := ratInCircle(a, b, c, p).Sign()
+		if got := sign(inCircle(a, b, c, p)); got != want {
+			t.Errorf("inCircle(%v, %v, %v, %v) sign %d, want %d", a, b, c, p, got, want)
+		}
+	})
+}
+
+func FuzzTriangulateNearDegenerate(f *testing.F) {
+	f.Add(int64(1), 1.0)
+	f.Add(int64(2), 1e-6)
+	f.Add(int64(3), 1e7)
+
+	f.Fuzz(func(t *testing.T, seed int64, scale float64) {
+		if !finite(scale) || scale < 1e-6 || scale > 1e9 {
+			t.Skip()
+		}
+		for _, points := range [][]Point{nearCollinear(seed, scale), nearCocircular(seed, scale)} {
+			d := runTriangulation(t, points)
+			assertMeshConsistent(t, d)
+		}
+	})
+}
+
+func TestTriangulateAtExtremeScales(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		scale  float64
+		offset float64
+	}{
+		{name: "Micrometres", points: generateTestPoints(200, 9), scale: 1e-6},
+		{name: "Millimetres", points: generateTestPoints(200, 9), scale: 1e6, offset: 1e9},
+		{name: "Cocircular Grid Micrometres", points: generateGrid(8), scale: 1e-5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := runTriangulation(t, tt.points)
+
+			scaled := make([]Point, len(tt.points))
+			for i, p := range tt.points {
+				scaled[i] = Point{p.X*tt.scale + tt.offset, p.Y*tt.scale + tt.offset}
+			}
+			d := runTriangulation(t, scaled)
+
+			assertMeshConsistent(t, d)
+			if got, want := d.TriangleCount(), base.TriangleCount(); got != want {
+				t.Errorf("Expected %d triangles as at unit scale, got %d", want, got)
+			}
+			if got := len(d.ExportGraph()); got != d.TriangleCount() {
+				t.Errorf("Expected a graph node per triangle, got %d for %d", got, d.TriangleCount())
+			}
+		})
+	}
+}
+
+// nearCollinear places points on a shallow line; rounding pushes them
+// fractionally off it in both directions.
+func nearCollinear(seed int64, scale float64) []Point {
+	r := rand.New(rand.NewSource(seed))
+	points := []Point{{0, scale}} // One point off the line so a mesh exists
+	for i := 0; i < 40; i++ {
+		x := r.Float64() * scale
+		points = append(points, Point{x, x * 0.1})
+	}
+	return points
+}
+
+// nearCocircular places points on a circle; their rounded coordinates are
+// almost, but not exactly, cocircular.
+func nearCocircular(seed int64, scale float64) []Point {
+	r := rand.New(rand.NewSource(seed))
+	points := make([]Point, 0, 40)
+	for i := 0; i < 40; i++ {
+		theta := r.Float64() * 2 * math.Pi
+		points = append(points, Point{scale * math.Cos(theta), scale * math.Sin(theta)})
+	}
+	return points
+}
+
+func ratOrient(a, b, c Point) *big.Rat {
+	bax, bay := sub(rat(b.X), rat(a.X)), sub(rat(b.Y), rat(a.Y))
+	cax, cay := sub(rat(c.X), rat(a.X)), sub(rat(c.Y), rat(a.Y))
+	return sub(mul(bax, cay), mul(bay, cax))
+}
+
+func ratInCircle(a, b, c, p Point) *big.Rat {
+	adx, ady := sub(rat(a.X), rat(p.X)), sub(rat(a.Y), rat(p.Y))
+	bdx, bdy := sub(rat(b.X), rat(p.X)), sub(rat(b.Y), rat(p.Y))
+	cdx, cdy := sub(rat(c.X), rat(p.X)), sub(rat(c.Y), rat(p.Y))
+
+	alift := new(big.Rat).Add(mul(adx, adx), mul(ady, ady))
+	blift := new(big.Rat).Add(mul(bdx, bdx), mul(bdy, bdy))
+	clift := new(big.Rat).Add(mul(cdx, cdx), mul(cdy, cdy))
+
+	det := mul(alift, sub(mul(bdx, cdy), mul(cdx, bdy)))
+	det.Add(det, mul(blift, sub(mul(cdx, ady), mul(adx, cdy))))
+	det.Add(det, mul(clift, sub(mul(adx, bdy), mul(bdx, ady))))
+	return det
+}
+
+func rat(x float64) *big.Rat     { return new(big.Rat).SetFloat64(x) }
+func sub(x, y *big.Rat) *big.Rat { return new(big.Rat).Sub(x, y) }
+func mul(x, y *big.Rat) *big.Rat { return new(big.Rat).Mul(x, y) }
+func sign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+func finite(xs ...float64) bool {
+	for _, x := range xs {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// bounded keeps fuzz inputs clear of overflow in the lifted determinant.
+func bounded(limit float64, xs ...float64) bool {
+	for _, x := range xs {
+		if math.Abs(x) > limit || (x != 0 && math.Abs(x) < 1/limit) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+package algo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRefine(t *testing.T) {
+	square := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	hole := []Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}}
+	strip := []Point{{0, 0}, {20, 0}, {20, 1}, {0, 1}}
+	slit := []Point{{3, 0.5}, {17, 0.5}}
+
+	tests := []struct {
+		name     string
+		points   []Point
+		chains   [][]Point // Constrained polylines; closed if the first point repeats
+		classify bool
+		opts     RefineOptions
+	}{
+		{name: "Random Points", points: generateTestPoints(200, 12), opts: RefineOptions{MinAngle: 20}},
+		{name: "Cocircular Grid", points: generateGrid(6), opts: RefineOptions{MinAngle: 30, MaxArea: 0.2}},
+		{name: "Area Bound", points: square, opts: RefineOptions{MaxArea: 2}},
+		{
+			name:     "Classified Hole",
+			points:   append(append([]Point(nil), square...), hole...),
+			chains:   [][]Point{append(square, square[0]), append(hole, hole[0])},
+			classify: true,
+			opts:     RefineOptions{MinAngle: 25, MaxArea: 4},
+		},
+		{
+			name:   "Slit In Strip",
+			points: append(append([]Point(nil), strip...), slit...),
+			chains: [][]Point{append(strip, strip[0]), slit},
+			opts:   RefineOptions{MinAngle: 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, tt.points)
+			for _, chain := range tt.chains {
+				for i := 1; i < len(chain); i++ {
+					if err := d.AddConstraint(vertexAt(d, chain[i-1]), vertexAt(d, chain[i])); err != nil {
+						t.Fatalf("AddConstraint failed: %v", err)
+					}
+				}
+			}
+			if tt.classify {
+				d.ClassifyRegions()
+			}
+			segments := constrainedEdges(d)
+
+			added, err := d.Refine(tt.opts)
+			if err != nil {
+				t.Fatalf("Refine failed: %v", err)
+			}
+			if added == 0 {
+				t.Error("Expected Steiner points to be inserted")
+			}
+			assertMeshConsistent(t, d)
+
+			for i, tri := range d.Triangles {
+				if !tri.Active {
+					if tt.classify {
+						t.Errorf("Classified mesh left inactive triangle %d", i)
+					}
+					continue
+				}
+				if tt.classify && !tri.Inside {
+					t.Errorf("Triangle %d lost its Inside flag", i)
+				}
+				a, b, c := d.Points[tri.A], d.Points[tri.B], d.Points[tri.C]
+				if tt.opts.MinAngle > 0 && minAngle(a, b, c) < tt.opts.MinAngle-1e-9 {
+					t.Errorf("Triangle %d has angle %.2f below %.2f", i, minAngle(a, b, c), tt.opts.MinAngle)
+				}
+				if tt.opts.MaxArea > 0 && orient2d(a, b, c)/2 > tt.opts.MaxArea {
+					t.Errorf("Triangle %d has area %.2f above %.2f", i, orient2d(a, b, c)/2, tt.opts.MaxArea)
+				}
+			}
+
+			// Split segments are covered exactly by constrained sub-segments.
+			refined := constrainedEdges(d)
+			if got, want := totalLength(d, refined), totalLength(d, segments); math.Abs(got-want) > 1e-9*want {
+				t.Errorf("Constrained length changed from %v to %v", want, got)
+			}
+			for _, e := range refined {
+				p, q := d.Points[e[0]], d.Points[e[1]]
+				onSegment := false
+				for _, s := range segments {
+					a, b := d.Points[s[0]], d.Points[s[1]]
+					if math.Abs(orient2d(a, b, p)) <= 1e-9*distSq(a, b) && math.Abs(orient2d(a, b, q)) <= 1e-9*distSq(a, b) {
+						onSegment = true
+						break
+					}
+				}
+				if !onSegment {
+					t.Errorf("Constrained edge %d-%d does not lie on an input segment", e[0], e[1])
+				}
+			}
+		})
+	}
+}
+
+func TestRefineLimit(t *testing.T) {
+	// Two constraints meeting at a 5 degree angle cannot reach 30 degrees.
+	tip := Point{0, 0}
+	far := Point{20 * math.Cos(5*math.Pi/180), 20 * math.Sin(5*math.Pi/180)}
+	d := runTriangulation(t, []Point{tip, {20, 0}, far, {-5, 10}})
+	for _, end := range []Point{{20, 0}, far} {
+		if err := d.AddConstraint(vertexAt(d, tip), vertexAt(d, end)); err != nil {
+			t.Fatalf("AddConstraint failed: %v", err)
+		}
+	}
+
+	added, err := d.Refine(RefineOptions{MinAngle: 30, MaxSteiner: 50})
+	if !errors.Is(err, ErrRefineLimit) {
+		t.Fatalf("Expected ErrRefineLimit, got %v", err)
+	}
+	if added != 50 {
+		t.Errorf("Expected 50 Steiner points, got %d", added)
+	}
+	assertMeshConsistent(t, d)
+}
+
+func TestRefineRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		triangulate bool
+		opts        RefineOptions
+	}{
+		{name: "Before Triangulate", opts: RefineOptions{MinAngle: 20}},
+		{name: "Angle Too Large", triangulate: true, opts: RefineOptions{MinAngle: 40}},
+		{name: "Negative Angle", triangulate: true, opts: RefineOptions{MinAngle: -1}},
+		{name: "NaN Area", triangulate: true, opts: RefineOptions{MaxArea: math.NaN()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDelaunay(generateTestPoints(20, 1))
+			if err != nil {
+				t.Fatalf("Failed to initialise: %v", err)
+			}
+			if tt.triangulate {
+				d.Triangulate()
+			}
+			if _, err := d.Refine(tt.opts); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func minAngle(a, b, c Point) float64 {
+	angle := func(p, q, r Point) float64 {
+		ux, uy, vx, vy := q.X-p.X, q.Y-p.Y, r.X-p.X, r.Y-p.Y
+		return math.Abs(math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)) * 180 / math.Pi
+	}
+	return math.Min(angle(a, b, c), math.Min(angle(b, c, a), angle(c, a, b)))
+}
+
+// constrainedEdges lists each constrained edge of the mesh once.
+func constrainedEdges(d *Delaunay) [][2]int32 {
+	var edges [][2]int32
+	for i, tri := range d.Triangles {
+		verts := [3]int32{tri.A, tri.B, tri.C}
+		for slot, n := range [3]int32{tri.T1, tri.T2, tri.T3} {
+			if tri.Active && tri.Constrained[slot] && (n == -1 || int(n) > i) {
+				edges = append(edges, [2]int32{verts[(slot+1)%3], verts[(slot+2)%3]})
+			}
+		}
+	}
+	return edges
+}
+
+func totalLength(d *Delaunay, edges [][2]int32) float64 {
+	total := 0.0
+	for _, e := range edges {
+		total += dist(d.Points[e[0]], d.Points[e[1]])
+	}
+	return total
+}
@@ -0,0 +1,117 @@
+package algo
+
+import (
+	"context"
+	"testing"
+)
+
+// nestedSquares returns four concentric square rings with room outside them.
+func nestedSquares() (points []Point, rings [][]Point) {
+	for _, half := range []float64{6, 4, 2, 1} {
+		ring := []Point{{6 - half, 6 - half}, {6 + half, 6 - half}, {6 + half, 6 + half}, {6 - half, 6 + half}}
+		rings = append(rings, ring)
+		points = append(points, ring...)
+	}
+	return append(points, Point{-4, 6}, Point{16, 6}), rings
+}
+
+// depthProbes are points in the gap between consecutive nested squares,
+// indexed by depth.
+var depthProbes = []Point{{-2, 6}, {1, 1}, {3, 3}, {4.5, 4.5}, {6, 6}}
+
+func addRings(t *testing.T, d *Delaunay, rings [][]Point) {
+	t.Helper()
+	for _, ring := range rings {
+		for i := range ring {
+			if err := d.AddConstraint(vertexAt(d, ring[i]), vertexAt(d, ring[(i+1)%len(ring)])); err != nil {
+				t.Fatalf("AddConstraint failed: %v", err)
+			}
+		}
+	}
+}
+
+func TestComputeDepths(t *testing.T) {
+	points, rings := nestedSquares()
+	d := runTriangulation(t, points)
+	addRings(t, d, rings)
+	d.ComputeDepths()
+
+	for want, p := range depthProbes {
+		tIdx := d.locate(p)
+		if tIdx == -1 {
+			t.Fatalf("Probe %v is not in the mesh", p)
+		}
+		if got := d.Triangles[tIdx].Depth; int(got) != want {
+			t.Errorf("Expected depth %d at %v, got %d", want, p, got)
+		}
+	}
+}
+
+func TestClassifyRegionsFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		keep func(depth int) bool
+	}{
+		{name: "Even-Odd", keep: nil}, // ClassifyRegions
+		{name: "All Solid", keep: func(depth int) bool { return depth > 0 }},
+		{name: "Holes Only", keep: func(depth int) bool { return depth > 0 && depth%2 == 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, rings := nestedSquares()
+			d := runTriangulation(t, points)
+			addRings(t, d, rings)
+
+			keep := tt.keep
+			if keep == nil {
+				d.ClassifyRegions()
+				keep = func(depth int) bool { return depth%2 == 1 }
+			} else {
+				d.ClassifyRegionsFunc(func(tri Triangle) bool { return keep(int(tri.Depth)) })
+			}
+
+			assertMeshConsistent(t, d)
+			for i, tri := range d.Triangles {
+				if !tri.Active || !keep(int(tri.Depth)) {
+					t.Errorf("Triangle %d at depth %d should have been removed", i, tri.Depth)
+				}
+			}
+			for depth, p := range depthProbes {
+				if kept := d.locate(p) != -1; kept != keep(depth) {
+					t.Errorf("Depth %d probe %v kept = %v, want %v", depth, p, kept, keep(depth))
+				}
+			}
+		})
+	}
+}
+
+func TestClassifyRegionsFuncContext(t *testing.T) {
+	// Two sibling squares at depth 1; only the left one is kept
+	left := []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+	right := []Point{{6, 0}, {10, 0}, {10, 4}, {6, 4}}
+	d := runTriangulation(t, append(append([]Point{}, left...), right...))
+	addRings(t, d, [][]Point{left, right})
+
+	err := d.ClassifyRegionsFuncContext(context.Background(), func(tri Triangle) bool {
+		return tri.Depth == 1 && d.Points[tri.A].X < 5
+	})
+	if err != nil {
+		t.Fatalf("ClassifyRegionsFuncContext failed: %v", err)
+	}
+
+	assertMeshConsistent(t, d)
+	probes := []struct {
+		p    Point
+		kept bool
+	}{
+		{p: Point{2, 2}, kept: true},
+		{p: Point{8, 2}, kept: false},
+		{p: Point{5, 2}, kept: false},
+	}
+	for _, pr := range probes {
+		if kept := d.locate(pr.p) != -1; kept != pr.kept {
+			t.Errorf("Probe %v kept = %v, want %v", pr.p, kept, pr.kept)
+		}
+	}
+}
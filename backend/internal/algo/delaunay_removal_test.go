@@ -0,0 +1,149 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRemovePoint(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		remove Point
+	}{
+		{
+			name:   "Interior Vertex",
+			points: []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {5, 5}},
+			remove: Point{5, 5},
+		},
+		{
+			name:   "Hull Vertex",
+			points: []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {5, 5}},
+			remove: Point{10, 10},
+		},
+		{
+			name:   "Collinear Hull Vertex",
+			points: []Point{{0, 0}, {5, 0}, {10, 0}, {5, 5}, {5, 10}},
+			remove: Point{5, 0},
+		},
+		{
+			name:   "Cocircular Grid",
+			points: generateGrid(5),
+			remove: Point{2, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, tt.points)
+			idx := vertexAt(d, tt.remove)
+
+			if err := d.RemovePoint(idx); err != nil {
+				t.Fatalf("RemovePoint failed: %v", err)
+			}
+
+			assertMeshConsistent(t, d)
+			if d.incidentTriangle(idx) != -1 {
+				t.Errorf("Vertex %d is still referenced after removal", idx)
+			}
+		})
+	}
+}
+
+func TestRemovePointMatchesRetriangulation(t *testing.T) {
+	points := generateTestPoints(200, 7)
+	d := runTriangulation(t, points)
+
+	removed := 0
+	for idx := 0; idx < len(d.Points) && removed < 50; idx += 3 {
+		if d.incidentTriangle(idx) == -1 {
+			continue
+		}
+		if err := d.RemovePoint(idx); err != nil {
+			t.Fatalf("RemovePoint(%d) failed: %v", idx, err)
+		}
+		removed++
+	}
+	assertMeshConsistent(t, d)
+
+	// Delaunay triangulations of points in general position are unique, so
+	// the edited mesh must have as many triangles as a fresh one.
+	remaining := []Point{}
+	for i, p := range d.Points {
+		if d.incidentTriangle(i) != -1 {
+			remaining = append(remaining, p)
+		}
+	}
+	fresh := runTriangulation(t, remaining)
+	if got, want := d.TriangleCount(), fresh.TriangleCount(); got != want {
+		t.Errorf("Expected %d triangles after removals, got %d", want, got)
+	}
+}
+
+func TestRemovePointRejects(t *testing.T) {
+	d := runTriangulation(t, generateGrid(4))
+	u, v := vertexAt(d, Point{1, 1}), vertexAt(d, Point{2, 1})
+	if err := d.AddConstraint(u, v); err != nil {
+		t.Fatalf("AddConstraint failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		idx  int
+	}{
+		{name: "Constraint Endpoint", idx: u},
+		{name: "Super Vertex", idx: d.superIndices[0]},
+		{name: "Out Of Range", idx: len(d.Points)},
+		{name: "Negative", idx: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := d.TriangleCount()
+			if err := d.RemovePoint(tt.idx); err == nil {
+				t.Fatal("Expected an error")
+			}
+			if d.TriangleCount() != before {
+				t.Error("Mesh changed despite refused removal")
+			}
+		})
+	}
+	assertMeshConsistent(t, d)
+}
+
+func TestRemovePointSplitFan(t *testing.T) {
+	d := runTriangulation(t, generateGrid(4))
+	// Keep the cells [0,1]x[0,1] and [1,2]x[1,2], which meet only at (1, 1)
+	d.ClassifyRegionsFunc(func(tri Triangle) bool {
+		cx := (d.Points[tri.A].X + d.Points[tri.B].X + d.Points[tri.C].X) / 3
+		cy := (d.Points[tri.A].Y + d.Points[tri.B].Y + d.Points[tri.C].Y) / 3
+		return math.Floor(cx) == math.Floor(cy) && cx < 2
+	})
+	pinch := vertexAt(d, Point{1, 1})
+	if !d.splitFans[pinch] {
+		t.Fatal("Expected the fan at (1, 1) to be flagged as split")
+	}
+
+	before := d.TriangleCount()
+	if err := d.RemovePoint(pinch); err == nil {
+		t.Fatal("Expected an error for a vertex joining two pieces")
+	}
+	if d.TriangleCount() != before {
+		t.Error("Mesh changed despite refused removal")
+	}
+	assertMeshConsistent(t, d)
+
+	// Corners whose fan is whole still go
+	if err := d.RemovePoint(vertexAt(d, Point{0, 0})); err != nil {
+		t.Errorf("RemovePoint failed: %v", err)
+	}
+	assertMeshConsistent(t, d)
+}
+
+// assertMeshConsistent fails the test for every violation Validate reports.
+func assertMeshConsistent(t *testing.T, d *Delaunay) {
+	t.Helper()
+	for _, v := range d.Validate() {
+		t.Errorf("Mesh violation: %v", v)
+	}
+}
@@ -0,0 +1,124 @@
+package algo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSlotReuse(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	d := runTriangulation(t, generateTestPoints(300, 11))
+	peak := d.TriangleCount()
+
+	// Insert and remove points in rounds, as an editing session would
+	for round := 0; round < 20; round++ {
+		for i := 0; i < 40; i++ {
+			if _, err := d.InsertPoint(Point{r.Float64() * 100, r.Float64() * 100}); err != nil {
+				t.Fatalf("InsertPoint failed: %v", err)
+			}
+			peak = max(peak, d.TriangleCount())
+		}
+		for i := 0; i < 40; i++ {
+			d.RemovePoint(r.Intn(len(d.Points))) // Refusals leave the mesh as it was
+		}
+		assertSlotsFree(t, d)
+	}
+	assertMeshConsistent(t, d)
+
+	// A single edit briefly holds both its old and new triangles
+	if len(d.Triangles) > peak+32 {
+		t.Errorf("Expected retired slots to be reused: %d slots for at most %d triangles", len(d.Triangles), peak)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	d := runTriangulation(t, generateTestPoints(200, 4))
+	for _, p := range []Point{{-10, -10}, {50, 50}, {25.5, 75.25}} {
+		if _, err := d.InsertPoint(p); err != nil {
+			t.Fatalf("InsertPoint failed: %v", err)
+		}
+	}
+	for v := 0; v < 40; v++ {
+		d.RemovePoint(v)
+	}
+	if len(d.free) == 0 {
+		t.Fatal("Expected retired slots before compacting")
+	}
+
+	type corners [3]int32
+	before := make([]corners, len(d.Triangles))
+	for i, tri := range d.Triangles {
+		before[i] = corners{tri.A, tri.B, tri.C}
+	}
+	wasActive := make([]bool, len(d.Triangles))
+	for i, tri := range d.Triangles {
+		wasActive[i] = tri.Active
+	}
+
+	mapping := d.Compact()
+
+	if len(mapping) != len(before) {
+		t.Fatalf("Expected a mapping for %d slots, got %d", len(before), len(mapping))
+	}
+	for old, idx := range mapping {
+		switch {
+		case !wasActive[old] && idx != -1:
+			t.Errorf("Retired slot %d mapped to %d", old, idx)
+		case wasActive[old] && idx == -1:
+			t.Errorf("Active triangle %d was dropped", old)
+		case wasActive[old]:
+			tri := d.Triangles[idx]
+			if got := (corners{tri.A, tri.B, tri.C}); got != before[old] {
+				t.Errorf("Triangle %d moved to %d as %v, want %v", old, idx, got, before[old])
+			}
+		}
+	}
+	if len(d.Triangles) != d.TriangleCount() || len(d.free) != 0 {
+		t.Errorf("Expected no inactive slots, got %d slots for %d triangles", len(d.Triangles), d.TriangleCount())
+	}
+	assertMeshConsistent(t, d)
+	assertVertexIndex(t, d)
+}
+
+func TestTriangleCount(t *testing.T) {
+	d := runTriangulation(t, generateTestPoints(200, 4))
+	before := d.TriangleCount()
+	if before != len(d.Triangles) {
+		t.Fatalf("Expected %d triangles in a fresh mesh, got %d", len(d.Triangles), before)
+	}
+
+	// Each interior removal leaves two slots retired until they are reused
+	removed := 0
+	for v := 0; v < 40; v++ {
+		if d.RemovePoint(v) == nil {
+			removed++
+		}
+	}
+	if len(d.free) == 0 {
+		t.Fatal("Expected retired slots after removing points")
+	}
+
+	want := len(d.ExportMesh().Triangles) / 3
+	if got := d.TriangleCount(); got != want {
+		t.Errorf("Expected %d triangles, got %d from %d slots", want, got, len(d.Triangles))
+	}
+	if removed == 0 || d.TriangleCount() >= before {
+		t.Errorf("Expected fewer than %d triangles after %d removals, got %d", before, removed, d.TriangleCount())
+	}
+}
+
+// assertSlotsFree checks every inactive slot is on the free list once, so
+// none is leaked or handed out twice.
+func assertSlotsFree(t *testing.T, d *Delaunay) {
+	t.Helper()
+	free := make(map[int32]bool, len(d.free))
+	for _, tIdx := range d.free {
+		if free[tIdx] || d.Triangles[tIdx].Active {
+			t.Fatalf("Slot %d is on the free list twice or still active", tIdx)
+		}
+		free[tIdx] = true
+	}
+	if inactive := len(d.Triangles) - d.TriangleCount(); inactive != len(free) {
+		t.Errorf("Expected %d inactive slots on the free list, got %d", inactive, len(free))
+	}
+}
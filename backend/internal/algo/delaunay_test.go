@@ -387,6 +387,30 @@ func TestDegeneracyPathologicalGeometries(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	d := runTriangulation(t, generateGrid(4))
+	u, v := vertexAt(d, Point{0, 0}), vertexAt(d, Point{3, 3})
+	if err := d.AddConstraint(u, v); err != nil {
+		t.Fatalf("AddConstraint failed: %v", err)
+	}
+	points, triangles := len(d.Points), d.TriangleCount()
+
+	c := d.Clone()
+	if _, err := c.InsertPoint(Point{1.5, 0.5}); err != nil {
+		t.Fatalf("InsertPoint failed: %v", err)
+	}
+	c.ClassifyRegions()
+
+	if len(d.Points) != points || d.TriangleCount() != triangles {
+		t.Errorf("Editing the clone changed the original: %d points, %d triangles", len(d.Points), d.TriangleCount())
+	}
+	if err := d.RemoveConstraint(u, v); err != nil {
+		t.Errorf("RemoveConstraint on the original failed: %v", err)
+	}
+	assertMeshConsistent(t, d)
+	assertMeshConsistent(t, c)
+}
+
 // ========================================
 // HELPERS
 // ========================================
@@ -0,0 +1,107 @@
+package algo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTracerRecordsSteps(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		want   []TraceKind // Kinds that must appear
+	}{
+		{name: "Random Points", points: generateTestPoints(50, 2), want: []TraceKind{TraceWalk, TraceSplit3, TraceInCircle, TraceFlip}},
+		{name: "Collinear Grid", points: generateGrid(4), want: []TraceKind{TraceSplit4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDelaunay(tt.points)
+			if err != nil {
+				t.Fatalf("Failed to initialise: %v", err)
+			}
+			rec := &TraceRecorder{}
+			d.SetTracer(rec)
+			d.Triangulate()
+
+			counts := make(map[TraceKind]int)
+			for i, e := range rec.Events {
+				counts[e.Kind]++
+				// Lawson's flips are exactly the in-circle tests that failed.
+				if e.Kind == TraceFlip {
+					if i == 0 || rec.Events[i-1].Kind != TraceInCircle || !rec.Events[i-1].Inside {
+						t.Errorf("Flip %d was not preceded by a failed in-circle test", i)
+					}
+				}
+				if e.Kind == TraceInCircle {
+					if e.Inside != (inCircle(d.Points[e.Vertices[0]], d.Points[e.Vertices[1]], d.Points[e.Vertices[2]], d.Points[e.Vertices[3]]) > 0) {
+						t.Errorf("In-circle event %d recorded the wrong result", i)
+					}
+				}
+			}
+			for _, kind := range tt.want {
+				if counts[kind] == 0 {
+					t.Errorf("Expected %v events, got none", kind)
+				}
+			}
+
+			// Tracing off records nothing more.
+			d.SetTracer(nil)
+			before := len(rec.Events)
+			if _, err := d.InsertPoint(Point{1.5, 1.5}); err != nil {
+				t.Fatalf("InsertPoint failed: %v", err)
+			}
+			if len(rec.Events) != before {
+				t.Errorf("Expected no events after SetTracer(nil), got %d", len(rec.Events)-before)
+			}
+		})
+	}
+}
+
+func TestTraceJSON(t *testing.T) {
+	d, err := NewDelaunay([]Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {4, 5}})
+	if err != nil {
+		t.Fatalf("Failed to initialise: %v", err)
+	}
+	rec := &TraceRecorder{}
+	d.SetTracer(rec)
+	d.Triangulate()
+
+	out, err := d.TraceJSON(rec.Events)
+	if err != nil {
+		t.Fatalf("TraceJSON failed: %v", err)
+	}
+	var trace struct {
+		Points []struct{ X, Y float64 } `json:"points"`
+		Events []struct {
+			Kind     string          `json:"kind"`
+			Vertices []int           `json:"vertices"`
+			Target   json.RawMessage `json:"target"`
+			Inside   *bool           `json:"inside"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(out), &trace); err != nil {
+		t.Fatalf("TraceJSON output is not valid JSON: %v", err)
+	}
+
+	if len(trace.Points) != len(d.Points) {
+		t.Errorf("Expected %d points, got %d", len(d.Points), len(trace.Points))
+	}
+	if len(trace.Events) != len(rec.Events) {
+		t.Fatalf("Expected %d events, got %d", len(rec.Events), len(trace.Events))
+	}
+	for i, e := range trace.Events {
+		if e.Kind != rec.Events[i].Kind.String() {
+			t.Errorf("Event %d has kind %q, want %q", i, e.Kind, rec.Events[i].Kind)
+		}
+		if (e.Target != nil) != (e.Kind == "walk") || (e.Inside != nil) != (e.Kind == "incircle") {
+			t.Errorf("Event %d (%s) has the wrong optional fields", i, e.Kind)
+		}
+		for _, v := range e.Vertices {
+			if v < 0 || v >= len(trace.Points) {
+				t.Errorf("Event %d refers to missing point %d", i, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package algo
+
+import "testing"
+
+func TestValidateSoundMeshes(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T) *Delaunay
+	}{
+		{
+			name: "Random Points",
+			setup: func(t *testing.T) *Delaunay {
+				return runTriangulation(t, generateTestPoints(300, 4))
+			},
+		},
+		{
+			name: "Cocircular Grid",
+			setup: func(t *testing.T) *Delaunay {
+				return runTriangulation(t, generateGrid(10))
+			},
+		},
+		{
+			name: "Constrained Diagonal",
+			setup: func(t *testing.T) *Delaunay {
+				d := runTriangulation(t, generateTestPoints(100, 6))
+				if err := d.AddConstraint(0, 1); err != nil {
+					t.Fatalf("AddConstraint failed: %v", err)
+				}
+				return d
+			},
+		},
+		{
+			name: "Classified With Hole",
+			setup: func(t *testing.T) *Delaunay {
+				hole := []Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}}
+				d := runTriangulation(t, append([]Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}, hole...))
+				for i := range hole {
+					u, v := vertexAt(d, hole[i]), vertexAt(d, hole[(i+1)%len(hole)])
+					if err := d.AddConstraint(u, v); err != nil {
+						t.Fatalf("AddConstraint failed: %v", err)
+					}
+				}
+				d.ClassifyRegions()
+				return d
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.setup(t)
+			if violations := d.Validate(); len(violations) != 0 {
+				t.Errorf("Expected a sound mesh, got %d violations, first: %v", len(violations), violations[0])
+			}
+		})
+	}
+}
+
+func TestValidateReportsCorruption(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(t *testing.T, d *Delaunay)
+		want    ViolationKind
+	}{
+		{
+			name: "Clockwise Triangle",
+			corrupt: func(t *testing.T, d *Delaunay) {
+				tIdx, _, _ := interiorEdge(t, d)
+				tri := &d.Triangles[tIdx]
+				tri.B, tri.C = tri.C, tri.B
+			},
+			want: ViolationOrientation,
+		},
+		{
+			name: "One-Way Link",
+			corrupt: func(t *testing.T, d *Delaunay) {
+				tIdx, slot, _ := interiorEdge(t, d)
+				d.setNeighbor(tIdx, slot, -1)
+			},
+			want: ViolationNeighbor,
+		},
+		{
+			name: "One-Sided Constraint",
+			corrupt: func(t *testing.T, d *Delaunay) {
+				tIdx, slot, _ := interiorEdge(t, d)
+				d.Triangles[tIdx].Constrained[slot] = true
+			},
+			want: ViolationConstraint,
+		},
+		{
+			name: "Illegal Flip",
+			corrupt: func(t *testing.T, d *Delaunay) {
+				for i, tri := range d.Triangles {
+					verts := [3]int32{tri.A, tri.B, tri.C}
+					for slot, n := range [3]int32{tri.T1, tri.T2, tri.T3} {
+						if !tri.Active || n == -1 {
+							continue
+						}
+						nb := d.Triangles[n]
+						q := [3]int32{nb.A, nb.B, nb.C}[d.neighborSlot(int(n), i)]
+						p, u, v := d.Points[verts[slot]], d.Points[verts[(slot+1)%3]], d.Points[verts[(slot+2)%3]]
+						if d.orient2d(p, u, d.Points[q]) > 0 && d.orient2d(p, d.Points[q], v) > 0 {
+							d.flipEdge(i, int(n))
+							return
+						}
+					}
+				}
+				t.Fatal("No convex quadrilateral to flip")
+			},
+			want: ViolationDelaunay,
+		},
+		{
+			name: "Triangle Pinned To Interior Vertex",
+			corrupt: func(t *testing.T, d *Delaunay) {
+				// A detached triangle hanging off the centre vertex keeps the
+				// links sound but makes the centre a non-manifold vertex.
+				centre := int32(vertexAt(d, Point{4, 5}))
+				d.Points = append(d.Points, Point{20, 20}, Point{19, 22})
+				n := int32(len(d.Points))
+				d.Triangles = append(d.Triangles, Triangle{A: centre, B: n - 2, C: n - 1, T1: -1, T2: -1, T3: -1, Active: true})
+			},
+			want: ViolationEuler,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := runTriangulation(t, []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {4, 5}})
+			tt.corrupt(t, d)
+
+			violations := d.Validate()
+			for _, v := range violations {
+				if v.Kind == tt.want {
+					return
+				}
+			}
+			t.Errorf("Expected a %v violation, got %v", tt.want, violations)
+		})
+	}
+}
+
+// interiorEdge returns the first active triangle edge with a neighbour.
+func interiorEdge(t *testing.T, d *Delaunay) (tIdx, slot, nIdx int) {
+	t.Helper()
+	for i, tri := range d.Triangles {
+		for s, n := range [3]int32{tri.T1, tri.T2, tri.T3} {
+			if tri.Active && n != -1 {
+				return i, s, int(n)
+			}
+		}
+	}
+	t.Fatal("Mesh has no interior edge")
+	return -1, -1, -1
+}
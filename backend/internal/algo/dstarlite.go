@@ -0,0 +1,351 @@
+package algo
+
+import (
+	"container/heap"
+	"math"
+)
+
+// DStarLite keeps incremental planner state over the triangle adjacency graph.
+// The search runs backwards from the goal, so when triangles are blocked or
+// freed only the g/rhs values they affect are repaired on the next Plan.
+// Triangle indices must stay stable while the planner is in use: obstacles are
+// expressed by blocking triangles, not by editing the mesh.
+// See docs/ALGORITHMS.md#4-pathfinding-heuristics-dynamic-fusion
+type DStarLite struct {
+	d       *Delaunay
+	graph   map[int]*GraphNode
+	blocked map[int]bool
+	g, rhs  map[int]float64
+	queue   *keyedQueue
+	km      float64
+
+	start, goal     int
+	startPt, goalPt Point
+	last            int
+
+	expanded int
+}
+
+// NewDStarLite attaches start and goal to their triangles and prepares an
+// empty planner. No search is done until Plan is called.
+func (d *Delaunay) NewDStarLite(start, goal Point) (*DStarLite, error) {
+	startTri := d.locate(start)
+	if startTri == -1 {
+		return nil, ErrStartBlocked
+	}
+	goalTri := d.locate(goal)
+	if goalTri == -1 {
+		return nil, ErrGoalBlocked
+	}
+
+	graph := d.ExportGraph()
+	if _, ok := graph[startTri]; !ok {
+		return nil, ErrStartBlocked
+	}
+	if _, ok := graph[goalTri]; !ok {
+		return nil, ErrGoalBlocked
+	}
+
+	p := &DStarLite{
+		d:       d,
+		graph:   graph,
+		blocked: make(map[int]bool),
+		g:       make(map[int]float64),
+		rhs:     make(map[int]float64),
+		queue:   newKeyedQueue(),
+		start:   startTri,
+		goal:    goalTri,
+		startPt: start,
+		goalPt:  goal,
+		last:    startTri,
+	}
+
+	p.rhs[goalTri] = 0
+	p.queue.set(goalTri, p.calculateKey(goalTri))
+	return p, nil
+}
+
+// Plan brings the g-values up to date and extracts the current best path.
+func (p *DStarLite) Plan() (*Path, error) {
+	p.expanded = 0
+	if p.blocked[p.start] {
+		return nil, ErrStartBlocked
+	}
+	if p.blocked[p.goal] {
+		return nil, ErrGoalBlocked
+	}
+
+	p.computeShortestPath()
+
+	if math.IsInf(p.value(p.g, p.start), 1) {
+		return nil, ErrNoPath
+	}
+
+	corridor := p.extractCorridor()
+	if corridor == nil {
+		return nil, ErrNoPath
+	}
+
+	startNode, goalNode := p.graph[p.start], p.graph[p.goal]
+	waypoints := make([]Point, 0, len(corridor)+2)
+	waypoints = append(waypoints, p.startPt)
+	for _, tIdx := range corridor {
+		waypoints = append(waypoints, Point{p.graph[tIdx].X, p.graph[tIdx].Y})
+	}
+	waypoints = append(waypoints, p.goalPt)
+
+	return &Path{
+		Waypoints: waypoints,
+		Triangles: corridor,
+		Cost: dist(p.startPt, Point{startNode.X, startNode.Y}) +
+			p.g[p.start] +
+			dist(Point{goalNode.X, goalNode.Y}, p.goalPt),
+	}, nil
+}
+
+// extractCorridor walks tight edges, where c(s, s') + g(s') == g(s), from the
+// start; any such chain to the goal is a shortest path. A BFS is used rather
+// than greedy descent because cocircular triangles share a circumcentre,
+// which gives zero-cost edges and therefore ties that can cycle.
+func (p *DStarLite) extractCorridor() []int {
+	parent := map[int]int{p.start: -1}
+	queue := []int{p.start}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if curr == p.goal {
+			break
+		}
+		gCurr := p.value(p.g, curr)
+		for _, n := range p.graph[curr].Neighbors {
+			if _, seen := parent[n]; seen {
+				continue
+			}
+			if math.Abs(p.cost(curr, n)+p.value(p.g, n)-gCurr) > EPSILON*math.Max(1, gCurr) {
+				continue
+			}
+			parent[n] = curr
+			queue = append(queue, n)
+		}
+	}
+
+	if _, ok := parent[p.goal]; !ok {
+		return nil
+	}
+	corridor := []int{}
+	for curr := p.goal; curr != -1; curr = parent[curr] {
+		corridor = append(corridor, curr)
+	}
+	for i, j := 0, len(corridor)-1; i < j; i, j = i+1, j-1 {
+		corridor[i], corridor[j] = corridor[j], corridor[i]
+	}
+	return corridor
+}
+
+// SetBlocked notifies the planner that a triangle became blocked or free.
+// Every edge into or out of the triangle changes cost; the affected rhs values
+// are repaired and queued for the next Plan.
+func (p *DStarLite) SetBlocked(tIdx int, blocked bool) {
+	node, ok := p.graph[tIdx]
+	if !ok || p.blocked[tIdx] == blocked {
+		return
+	}
+
+	type edge struct {
+		u, v int
+		old  float64
+	}
+	changed := make([]edge, 0, 2*len(node.Neighbors))
+	for _, n := range node.Neighbors {
+		changed = append(changed, edge{tIdx, n, p.cost(tIdx, n)}, edge{n, tIdx, p.cost(n, tIdx)})
+	}
+
+	if blocked {
+		p.blocked[tIdx] = true
+	} else {
+		delete(p.blocked, tIdx)
+	}
+
+	for _, e := range changed {
+		if e.u == p.goal {
+			continue
+		}
+		newCost := p.cost(e.u, e.v)
+		if e.old > newCost {
+			p.rhs[e.u] = math.Min(p.value(p.rhs, e.u), newCost+p.value(p.g, e.v))
+		} else if p.value(p.rhs, e.u) == e.old+p.value(p.g, e.v) {
+			p.rhs[e.u] = p.minSuccessor(e.u)
+		}
+		p.updateVertex(e.u)
+	}
+}
+
+// MoveStart moves the robot. The key modifier km absorbs the heuristic shift
+// so queued keys stay valid without reordering the queue.
+func (p *DStarLite) MoveStart(start Point) error {
+	tIdx := p.d.locate(start)
+	if _, ok := p.graph[tIdx]; tIdx == -1 || !ok {
+		return ErrStartBlocked
+	}
+	p.startPt = start
+	if tIdx == p.start {
+		return nil
+	}
+	p.start = tIdx
+	p.km += p.h(p.last, p.start)
+	p.last = p.start
+	return nil
+}
+
+// Expanded reports how many nodes the most recent Plan expanded.
+func (p *DStarLite) Expanded() int {
+	return p.expanded
+}
+
+func (p *DStarLite) computeShortestPath() {
+	for p.queue.Len() > 0 {
+		u, kOld := p.queue.top()
+		startKey := p.calculateKey(p.start)
+		if !keyLess(kOld, startKey) && p.value(p.rhs, p.start) == p.value(p.g, p.start) {
+			return
+		}
+
+		kNew := p.calculateKey(u)
+		gU, rhsU := p.value(p.g, u), p.value(p.rhs, u)
+		switch {
+		case keyLess(kOld, kNew):
+			p.queue.set(u, kNew)
+		case gU > rhsU:
+			// Locally overconsistent: settle u and relax its predecessors.
+			p.expanded++
+			p.g[u] = rhsU
+			p.queue.remove(u)
+			for _, s := range p.graph[u].Neighbors {
+				if s != p.goal {
+					p.rhs[s] = math.Min(p.value(p.rhs, s), p.cost(s, u)+rhsU)
+				}
+				p.updateVertex(s)
+			}
+		default:
+			// Locally underconsistent: invalidate u and anything routed through it.
+			p.expanded++
+			p.g[u] = math.Inf(1)
+			preds := make([]int, 0, len(p.graph[u].Neighbors)+1)
+			preds = append(preds, p.graph[u].Neighbors...)
+			for _, s := range append(preds, u) {
+				if s != p.goal && p.value(p.rhs, s) == p.cost(s, u)+gU {
+					p.rhs[s] = p.minSuccessor(s)
+				}
+				p.updateVertex(s)
+			}
+		}
+	}
+}
+
+func (p *DStarLite) updateVertex(u int) {
+	if p.value(p.g, u) != p.value(p.rhs, u) {
+		p.queue.set(u, p.calculateKey(u))
+	} else {
+		p.queue.remove(u)
+	}
+}
+
+func (p *DStarLite) calculateKey(s int) [2]float64 {
+	m := math.Min(p.value(p.g, s), p.value(p.rhs, s))
+	return [2]float64{m + p.h(p.start, s) + p.km, m}
+}
+
+func (p *DStarLite) minSuccessor(u int) float64 {
+	best := math.Inf(1)
+	for _, s := range p.graph[u].Neighbors {
+		best = math.Min(best, p.cost(u, s)+p.value(p.g, s))
+	}
+	return best
+}
+
+// cost is the circumcentre distance, or +Inf if either triangle is blocked.
+func (p *DStarLite) cost(u, v int) float64 {
+	if p.blocked[u] || p.blocked[v] {
+		return math.Inf(1)
+	}
+	node := p.graph[u]
+	for i, n := range node.Neighbors {
+		if n == v {
+			return node.Costs[i]
+		}
+	}
+	return math.Inf(1)
+}
+
+func (p *DStarLite) h(a, b int) float64 {
+	na, nb := p.graph[a], p.graph[b]
+	return math.Hypot(na.X-nb.X, na.Y-nb.Y)
+}
+
+// value reads g or rhs; unseen nodes are +Inf.
+func (p *DStarLite) value(m map[int]float64, s int) float64 {
+	if v, ok := m[s]; ok {
+		return v
+	}
+	return math.Inf(1)
+}
+
+func keyLess(a, b [2]float64) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+// keyedQueue is a binary heap supporting update and removal by node id,
+// which D* Lite needs and container/heap alone does not provide.
+type keyedQueue struct {
+	ids   []int
+	keys  map[int][2]float64
+	index map[int]int
+}
+
+func newKeyedQueue() *keyedQueue {
+	return &keyedQueue{keys: make(map[int][2]float64), index: make(map[int]int)}
+}
+
+func (q *keyedQueue) Len() int           { return len(q.ids) }
+func (q *keyedQueue) Less(i, j int) bool { return keyLess(q.keys[q.ids[i]], q.keys[q.ids[j]]) }
+func (q *keyedQueue) Swap(i, j int) {
+	q.ids[i], q.ids[j] = q.ids[j], q.ids[i]
+	q.index[q.ids[i]] = i
+	q.index[q.ids[j]] = j
+}
+
+func (q *keyedQueue) Push(x any) {
+	id := x.(int)
+	q.index[id] = len(q.ids)
+	q.ids = append(q.ids, id)
+}
+
+func (q *keyedQueue) Pop() any {
+	n := len(q.ids)
+	id := q.ids[n-1]
+	q.ids = q.ids[:n-1]
+	delete(q.index, id)
+	delete(q.keys, id)
+	return id
+}
+
+func (q *keyedQueue) top() (int, [2]float64) {
+	id := q.ids[0]
+	return id, q.keys[id]
+}
+
+// set inserts id or changes its key.
+func (q *keyedQueue) set(id int, key [2]float64) {
+	q.keys[id] = key
+	if i, ok := q.index[id]; ok {
+		heap.Fix(q, i)
+		return
+	}
+	heap.Push(q, id)
+}
+
+func (q *keyedQueue) remove(id int) {
+	if i, ok := q.index[id]; ok {
+		heap.Remove(q, i)
+	}
+}
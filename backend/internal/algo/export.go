@@ -0,0 +1,59 @@
+package algo
+
+// ExportMesh returns the active triangles as an IndexedMesh. Vertices no
+// active triangle uses are left out, and triangles and vertices are
+// renumbered densely in their original order. Before Triangulate finishes,
+// triangles touching the super triangle are left out too, which gives the
+// mesh of the points inserted so far.
+func (d *Delaunay) ExportMesh() IndexedMesh {
+	var mesh IndexedMesh
+
+	triIdx := make([]int32, len(d.Triangles))
+	count := int32(0)
+	for i, t := range d.Triangles {
+		triIdx[i] = -1
+		if t.Active && (d.superRemoved || !d.touchesSuper(t)) {
+			triIdx[i] = count
+			count++
+		}
+	}
+
+	vertIdx := make(map[int32]int32)
+	vertex := func(v int32) int32 {
+		if idx, ok := vertIdx[v]; ok {
+			return idx
+		}
+		idx := int32(len(mesh.Vertices))
+		vertIdx[v] = idx
+		mesh.Vertices = append(mesh.Vertices, d.Points[v])
+		mesh.Sources = append(mesh.Sources, int(v))
+		return idx
+	}
+
+	for i, t := range d.Triangles {
+		if triIdx[i] == -1 {
+			continue
+		}
+		for slot, n := range [3]int32{t.T1, t.T2, t.T3} {
+			mesh.Triangles = append(mesh.Triangles, vertex([3]int32{t.A, t.B, t.C}[slot]))
+			if n != -1 {
+				n = triIdx[n] // -1 if n was left out
+			}
+			mesh.Neighbors = append(mesh.Neighbors, n)
+			mesh.Constrained = append(mesh.Constrained, t.Constrained[slot])
+		}
+	}
+	return mesh
+}
+
+// touchesSuper reports whether t uses a vertex of the super triangle.
+func (d *Delaunay) touchesSuper(t Triangle) bool {
+	for _, v := range [3]int32{t.A, t.B, t.C} {
+		for _, s := range d.superIndices {
+			if int(v) == s {
+				return true
+			}
+		}
+	}
+	return false
+}
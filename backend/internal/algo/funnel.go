@@ -0,0 +1,144 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SmoothPath replaces the circumcentre waypoints of a path with the taut
+// path through the same triangle corridor. Triangles are kept unchanged.
+// For paths planned with a radius, the portals are first shrunk away from the
+// walls (see shrinkPortal), so corners sit off the vertices they wrap and,
+// like every funnel corner, stay inside the corridor. The path needs at
+// least a start and a goal waypoint.
+func (d *Delaunay) SmoothPath(path *Path) (*Path, error) {
+	if path == nil || len(path.Waypoints) < 2 {
+		return nil, errors.New("path needs a start and a goal waypoint")
+	}
+	start, goal := path.Waypoints[0], path.Waypoints[len(path.Waypoints)-1]
+	leftVerts, rightVerts, err := d.portals(path.Triangles)
+	if err != nil {
+		return nil, err
+	}
+	lefts, rights := d.portalPoints(leftVerts, rightVerts)
+	if path.Radius > 0 {
+		walls := d.wallVertices()
+		for i := range lefts {
+			lefts[i], rights[i] = shrinkPortal(lefts[i], rights[i], walls[leftVerts[i]], walls[rightVerts[i]], path.Radius)
+		}
+	}
+	waypoints := d.pullString(start, goal, lefts, rights)
+
+	cost := 0.0
+	for i := 1; i < len(waypoints); i++ {
+		cost += dist(waypoints[i-1], waypoints[i])
+	}
+	return &Path{Waypoints: waypoints, Triangles: path.Triangles, Cost: cost, Radius: path.Radius}, nil
+}
+
+// StringPull implements the Simple Stupid Funnel Algorithm (Mononen).
+// The corridor is crossed through the portal edges shared by consecutive
+// triangles; the funnel narrows until one side crosses the other, at which
+// point that side's vertex becomes a corner. Every corner is a mesh vertex.
+func (d *Delaunay) StringPull(start, goal Point, corridor []int) ([]Point, error) {
+	leftVerts, rightVerts, err := d.portals(corridor)
+	if err != nil {
+		return nil, err
+	}
+	lefts, rights := d.portalPoints(leftVerts, rightVerts)
+	return d.pullString(start, goal, lefts, rights), nil
+}
+
+// pullString runs the funnel through the given portals from start to goal.
+func (d *Delaunay) pullString(start, goal Point, lefts, rights []Point) []Point {
+	// The start and goal act as degenerate portals at either end.
+	lefts = append(append([]Point{start}, lefts...), goal)
+	rights = append(append([]Point{start}, rights...), goal)
+
+	path := []Point{start}
+	apex, left, right := start, start, start
+	apexIdx, leftIdx, rightIdx := 0, 0, 0
+
+	for i := 1; i < len(lefts); i++ {
+		l, r := lefts[i], rights[i]
+
+		// Tighten the right side if r does not widen the funnel.
+		if d.orient2d(apex, right, r) >= 0 {
+			if apex == right || d.orient2d(apex, left, r) < 0 {
+				right, rightIdx = r, i
+			} else {
+				// Right crossed over left: left vertex becomes a corner.
+				path = appendCorner(path, left)
+				apex, apexIdx = left, leftIdx
+				left, right = apex, apex
+				leftIdx, rightIdx = apexIdx, apexIdx
+				i = apexIdx
+				continue
+			}
+		}
+
+		// Tighten the left side if l does not widen the funnel.
+		if d.orient2d(apex, left, l) <= 0 {
+			if apex == left || d.orient2d(apex, right, l) > 0 {
+				left, leftIdx = l, i
+			} else {
+				// Left crossed over right: right vertex becomes a corner.
+				path = appendCorner(path, right)
+				apex, apexIdx = right, rightIdx
+				left, right = apex, apex
+				leftIdx, rightIdx = apexIdx, apexIdx
+				i = apexIdx
+				continue
+			}
+		}
+	}
+
+	return appendCorner(path, goal)
+}
+
+// appendCorner skips repeated corners, which occur when the start or goal
+// coincides with a mesh vertex on the first or last portal.
+func appendCorner(path []Point, p Point) []Point {
+	if path[len(path)-1] == p {
+		return path
+	}
+	return append(path, p)
+}
+
+// portals returns the left and right endpoint vertices of each edge shared by
+// consecutive corridor triangles, as seen when walking the corridor forwards.
+func (d *Delaunay) portals(corridor []int) ([]int32, []int32, error) {
+	lefts := make([]int32, 0, len(corridor))
+	rights := make([]int32, 0, len(corridor))
+
+	for i := 1; i < len(corridor); i++ {
+		t := d.Triangles[corridor[i-1]]
+		slot := -1
+		for s, n := range [3]int32{t.T1, t.T2, t.T3} {
+			if int(n) == corridor[i] {
+				slot = s
+				break
+			}
+		}
+		if slot == -1 {
+			return nil, nil, fmt.Errorf("corridor triangles %d and %d are not neighbours", corridor[i-1], corridor[i])
+		}
+
+		// Edge opposite slot runs (slot+1) -> (slot+2) CCW. Leaving the
+		// triangle across it, the first vertex is on the right.
+		verts := [3]int32{t.A, t.B, t.C}
+		rights = append(rights, verts[(slot+1)%3])
+		lefts = append(lefts, verts[(slot+2)%3])
+	}
+	return lefts, rights, nil
+}
+
+// portalPoints looks up the positions of the portal endpoint vertices.
+func (d *Delaunay) portalPoints(leftVerts, rightVerts []int32) ([]Point, []Point) {
+	lefts := make([]Point, len(leftVerts))
+	rights := make([]Point, len(rightVerts))
+	for i := range leftVerts {
+		lefts[i], rights[i] = d.Points[leftVerts[i]], d.Points[rightVerts[i]]
+	}
+	return lefts, rights
+}
@@ -1,30 +1,241 @@
 package algo
 
+import "math"
+
 // orient2d returns 2*SignedArea. Positive for counter-clockwise orientation.
+// The sign is exact for all inputs; see orient2d (package level).
 // See docs/MATHEMATICS.md#11-orientation-point-in-triangle
 func (d *Delaunay) orient2d(a, b, c Point) float64 {
-	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+	return orient2d(a, b, c)
 }
 
 func (d *Delaunay) inCircumcircle(tIdx int, p Point) bool {
 	t := d.Triangles[tIdx]
-	a, b, c := d.Points[int(t.A)], d.Points[int(t.B)], d.Points[int(t.C)]
-
-	// inCircumcircle tests if point is inside triangle's circumcircle.
-	// Uses robust determinant-based predicate to avoid explicit circumcentre calculation.
-	// See docs/MATHEMATICS.md#12-in-circle-test
-	ax, ay := a.X-p.X, a.Y-p.Y
-	bx, by := b.X-p.X, b.Y-p.Y
-	cx, cy := c.X-p.X, c.Y-p.Y
-
-	return (ax*ax+ay*ay)*(bx*cy-cx*by)-
-		(bx*bx+by*by)*(ax*cy-cx*ay)+
-		(cx*cx+cy*cy)*(ax*by-bx*ay) > EPSILON
+	return inCircle(d.Points[int(t.A)], d.Points[int(t.B)], d.Points[int(t.C)], p) > 0
 }
 
 func (d *Delaunay) contains(tIdx int, p Point) bool {
 	t := d.Triangles[tIdx]
-	return d.orient2d(d.Points[int(t.A)], d.Points[int(t.B)], p) >= -EPSILON &&
-		d.orient2d(d.Points[int(t.B)], d.Points[int(t.C)], p) >= -EPSILON &&
-		d.orient2d(d.Points[int(t.C)], d.Points[int(t.A)], p) >= -EPSILON
+	return d.orient2d(d.Points[int(t.A)], d.Points[int(t.B)], p) >= 0 &&
+		d.orient2d(d.Points[int(t.B)], d.Points[int(t.C)], p) >= 0 &&
+		d.orient2d(d.Points[int(t.C)], d.Points[int(t.A)], p) >= 0
+}
+
+// Shewchuk's adaptive predicates: the float determinant is returned when it
+// is larger than its worst-case rounding error, and the determinant is
+// otherwise recomputed exactly with floating-point expansions. Only the sign
+// of the result is exact; the magnitude is an approximation.
+// See docs/MATHEMATICS.md#13-exact-adaptive-predicates
+const (
+	machineEpsilon = 1.0 / (1 << 53) // Half an ulp of 1.0
+	ccwErrBound    = (3 + 16*machineEpsilon) * machineEpsilon
+	iccErrBound    = (10 + 96*machineEpsilon) * machineEpsilon
+)
+
+// orient2d is positive when a, b, c are counter-clockwise, negative when
+// clockwise and zero when collinear.
+func orient2d(a, b, c Point) float64 {
+	// Explicit conversions keep each product rounded, as the bound assumes.
+	detLeft := float64((a.X - c.X) * (b.Y - c.Y))
+	detRight := float64((a.Y - c.Y) * (b.X - c.X))
+	det := detLeft - detRight
+
+	var detSum float64
+	switch {
+	case detLeft > 0:
+		if detRight <= 0 {
+			return det
+		}
+		detSum = detLeft + detRight
+	case detLeft < 0:
+		if detRight >= 0 {
+			return det
+		}
+		detSum = -detLeft - detRight
+	default:
+		return det
+	}
+
+	if bound := ccwErrBound * detSum; det >= bound || -det >= bound {
+		return det
+	}
+	return orient2dExact(a, b, c)
+}
+
+// orient2dExact expands the determinant into six exact products:
+// ax*by - ax*cy - ay*bx + ay*cx + bx*cy - by*cx.
+func orient2dExact(a, b, c Point) float64 {
+	terms := [6][2]float64{
+		{a.X, b.Y}, {-a.X, c.Y}, {-a.Y, b.X}, {a.Y, c.X}, {b.X, c.Y}, {-b.Y, c.X},
+	}
+	var det []float64
+	for _, t := range terms {
+		det = sumExpansions(det, productExpansion(t[0], t[1]))
+	}
+	return estimate(det)
+}
+
+// inCircle is positive when p lies inside the circumcircle of the CCW
+// triangle abc, zero when cocircular and negative outside.
+// Uses robust determinant-based predicate to avoid explicit circumcentre calculation.
+// See docs/MATHEMATICS.md#12-in-circle-test
+func inCircle(a, b, c, p Point) float64 {
+	adx, ady := a.X-p.X, a.Y-p.Y
+	bdx, bdy := b.X-p.X, b.Y-p.Y
+	cdx, cdy := c.X-p.X, c.Y-p.Y
+
+	bdxcdy, cdxbdy := float64(bdx*cdy), float64(cdx*bdy)
+	cdxady, adxcdy := float64(cdx*ady), float64(adx*cdy)
+	adxbdy, bdxady := float64(adx*bdy), float64(bdx*ady)
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+
+	det := alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+
+	if bound := iccErrBound * permanent; det > bound || -det > bound {
+		return det
+	}
+	return inCircleExact(a, b, c, p)
+}
+
+// inCircleExact evaluates the lifted determinant with every difference and
+// product kept as an exact expansion.
+func inCircleExact(a, b, c, p Point) float64 {
+	adx, ady := diffExpansion(a.X, p.X), diffExpansion(a.Y, p.Y)
+	bdx, bdy := diffExpansion(b.X, p.X), diffExpansion(b.Y, p.Y)
+	cdx, cdy := diffExpansion(c.X, p.X), diffExpansion(c.Y, p.Y)
+
+	cross := func(ux, uy, vx, vy []float64) []float64 {
+		return sumExpansions(mulExpansions(ux, vy), negateExpansion(mulExpansions(vx, uy)))
+	}
+	lift := func(x, y []float64) []float64 {
+		return sumExpansions(mulExpansions(x, x), mulExpansions(y, y))
+	}
+
+	det := mulExpansions(lift(adx, ady), cross(bdx, bdy, cdx, cdy))
+	det = sumExpansions(det, mulExpansions(lift(bdx, bdy), cross(cdx, cdy, adx, ady)))
+	det = sumExpansions(det, mulExpansions(lift(cdx, cdy), cross(adx, ady, bdx, bdy)))
+	return estimate(det)
+}
+
+// An expansion is a sum of non-overlapping float64 components in increasing
+// order of magnitude, representing a value exactly.
+
+// twoSum returns x = fl(a+b) and the rounding error y, so a+b == x+y exactly.
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := x - a
+	av := x - bv
+	y = (a - av) + (b - bv)
+	return x, y
+}
+
+// twoProduct returns x = fl(a*b) and the rounding error y, so a*b == x+y exactly.
+func twoProduct(a, b float64) (x, y float64) {
+	x = float64(a * b) // Explicit conversion stops the compiler fusing into the FMA
+	y = math.FMA(a, b, -x)
+	return x, y
+}
+
+func productExpansion(a, b float64) []float64 {
+	x, y := twoProduct(a, b)
+	return []float64{y, x}
+}
+
+func diffExpansion(a, b float64) []float64 {
+	x, y := twoSum(a, -b)
+	return []float64{y, x}
+}
+
+// sumExpansions merges e and f by magnitude and accumulates the components
+// with twoSum, dropping zero components (Shewchuk's fast expansion sum).
+func sumExpansions(e, f []float64) []float64 {
+	if len(e) == 0 {
+		return f
+	}
+	if len(f) == 0 {
+		return e
+	}
+
+	merged := make([]float64, 0, len(e)+len(f))
+	i, j := 0, 0
+	for i < len(e) && j < len(f) {
+		if math.Abs(e[i]) < math.Abs(f[j]) {
+			merged = append(merged, e[i])
+			i++
+		} else {
+			merged = append(merged, f[j])
+			j++
+		}
+	}
+	merged = append(merged, e[i:]...)
+	merged = append(merged, f[j:]...)
+
+	h := make([]float64, 0, len(merged))
+	q := merged[0]
+	for _, g := range merged[1:] {
+		var err float64
+		q, err = twoSum(q, g)
+		if err != 0 {
+			h = append(h, err)
+		}
+	}
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// scaleExpansion multiplies an expansion by a single float exactly.
+func scaleExpansion(e []float64, b float64) []float64 {
+	h := make([]float64, 0, 2*len(e))
+	q, err := twoProduct(e[0], b)
+	if err != 0 {
+		h = append(h, err)
+	}
+	for _, c := range e[1:] {
+		hi, lo := twoProduct(c, b)
+		sum, err := twoSum(q, lo)
+		if err != 0 {
+			h = append(h, err)
+		}
+		q, err = twoSum(hi, sum)
+		if err != 0 {
+			h = append(h, err)
+		}
+	}
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+func mulExpansions(e, f []float64) []float64 {
+	var product []float64
+	for _, c := range f {
+		product = sumExpansions(product, scaleExpansion(e, c))
+	}
+	return product
+}
+
+func negateExpansion(e []float64) []float64 {
+	neg := make([]float64, len(e))
+	for i, c := range e {
+		neg[i] = -c
+	}
+	return neg
+}
+
+// estimate sums the components from smallest to largest. The largest
+// component dominates, so the sign of the estimate is exact.
+func estimate(e []float64) float64 {
+	sum := 0.0
+	for _, c := range e {
+		sum += c
+	}
+	return sum
 }
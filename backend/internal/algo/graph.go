@@ -13,39 +13,71 @@ func (d *Delaunay) ExportGraph() map[int]*GraphNode {
 			continue
 		}
 
-		p1, p2, p3 := d.Points[int(t.A)], d.Points[int(t.B)], d.Points[int(t.C)]
-
-		D := 2 * (p1.X*(p2.Y-p3.Y) + p2.X*(p3.Y-p1.Y) + p3.X*(p1.Y-p2.Y))
-		if math.Abs(D) < EPSILON {
+		cc, ok := d.circumcentre(i)
+		if !ok {
 			continue // Skip degenerate triangles (collinear vertices)
 		}
 
-		// See docs/MATHEMATICS.md#3-circumcenter-calculation
-		Ux := ((p1.X*p1.X+p1.Y*p1.Y)*(p2.Y-p3.Y) +
-			(p2.X*p2.X+p2.Y*p2.Y)*(p3.Y-p1.Y) +
-			(p3.X*p3.X+p3.Y*p3.Y)*(p1.Y-p2.Y)) / D
-
-		Uy := ((p1.X*p1.X+p1.Y*p1.Y)*(p3.X-p2.X) +
-			(p2.X*p2.X+p2.Y*p2.Y)*(p1.X-p3.X) +
-			(p3.X*p3.X+p3.Y*p3.Y)*(p2.X-p1.X)) / D
-
 		node := &GraphNode{
 			ID:        i,
-			X:         Ux,
-			Y:         Uy,
+			X:         cc.X,
+			Y:         cc.Y,
 			Neighbors: []int{},
 		}
 
-		addNeigh := func(nIdx int) {
+		// Constrained edges are obstacle walls, so they never become graph edges.
+		addNeigh := func(slot int, nIdx int) {
+			if t.Constrained[slot] {
+				return
+			}
 			if nIdx != -1 && nIdx < len(d.Triangles) && d.Triangles[nIdx].Active {
 				node.Neighbors = append(node.Neighbors, nIdx)
 			}
 		}
-		addNeigh(int(t.T1))
-		addNeigh(int(t.T2))
-		addNeigh(int(t.T3))
+		addNeigh(0, int(t.T1))
+		addNeigh(1, int(t.T2))
+		addNeigh(2, int(t.T3))
 
 		graph[i] = node
 	}
+
+	// Edge costs are the Euclidean distance between the two circumcentres.
+	// Neighbours skipped above as degenerate have no node, so drop them here.
+	for _, node := range graph {
+		neighbors := node.Neighbors[:0]
+		node.Costs = make([]float64, 0, len(node.Neighbors))
+		for _, nIdx := range node.Neighbors {
+			other, ok := graph[nIdx]
+			if !ok {
+				continue
+			}
+			neighbors = append(neighbors, nIdx)
+			node.Costs = append(node.Costs, math.Hypot(other.X-node.X, other.Y-node.Y))
+		}
+		node.Neighbors = neighbors
+	}
 	return graph
 }
+
+// circumcentre returns the circumcentre of triangle tIdx.
+// ok is false for degenerate (collinear) triangles.
+// See docs/MATHEMATICS.md#3-circumcentre-calculation
+func (d *Delaunay) circumcentre(tIdx int) (Point, bool) {
+	t := d.Triangles[tIdx]
+	p1, p2, p3 := d.Points[int(t.A)], d.Points[int(t.B)], d.Points[int(t.C)]
+
+	D := 2 * (p1.X*(p2.Y-p3.Y) + p2.X*(p3.Y-p1.Y) + p3.X*(p1.Y-p2.Y))
+	if D == 0 || orient2d(p1, p2, p3) == 0 {
+		return Point{}, false
+	}
+
+	Ux := ((p1.X*p1.X+p1.Y*p1.Y)*(p2.Y-p3.Y) +
+		(p2.X*p2.X+p2.Y*p2.Y)*(p3.Y-p1.Y) +
+		(p3.X*p3.X+p3.Y*p3.Y)*(p1.Y-p2.Y)) / D
+
+	Uy := ((p1.X*p1.X+p1.Y*p1.Y)*(p3.X-p2.X) +
+		(p2.X*p2.X+p2.Y*p2.Y)*(p1.X-p3.X) +
+		(p3.X*p3.X+p3.Y*p3.Y)*(p2.X-p1.X)) / D
+
+	return Point{Ux, Uy}, true
+}
@@ -0,0 +1,118 @@
+package algo
+
+import "iter"
+
+// The triangles double as a half-edge mesh. An EdgeRef is the half-edge of
+// triangle TIdx running counter-clockwise from vertex EdgeIdx+1 to EdgeIdx+2,
+// so Next and Prev stay within the triangle and Twin crosses to the neighbour
+// stored in the same slot, reversed. The neighbour borders its twin in one of
+// three slots, so every step is O(1) without storing twins separately, and
+// code walking the mesh never has to match vertices to find a shared edge.
+// A hull half-edge has no twin; Twin returns TIdx -1 for it.
+
+// noEdge is the half-edge returned when there is none.
+var noEdge = EdgeRef{TIdx: -1, EdgeIdx: -1}
+
+// Next returns the half-edge starting where e ends, in the same triangle.
+func (e EdgeRef) Next() EdgeRef {
+	return EdgeRef{TIdx: e.TIdx, EdgeIdx: (e.EdgeIdx + 1) % 3}
+}
+
+// Prev returns the half-edge ending where e starts, in the same triangle.
+func (e EdgeRef) Prev() EdgeRef {
+	return EdgeRef{TIdx: e.TIdx, EdgeIdx: (e.EdgeIdx + 2) % 3}
+}
+
+// Twin returns the half-edge running the other way along e in the
+// neighbouring triangle, or TIdx -1 if e is on the hull.
+func (d *Delaunay) Twin(e EdgeRef) EdgeRef {
+	n := d.getNeighborIdx(e)
+	if n == -1 {
+		return noEdge
+	}
+	return EdgeRef{TIdx: n, EdgeIdx: d.neighborSlot(n, e.TIdx)}
+}
+
+// Origin returns the vertex e starts from.
+func (d *Delaunay) Origin(e EdgeRef) int {
+	return d.corner(e.TIdx, (e.EdgeIdx+1)%3)
+}
+
+// Dest returns the vertex e ends at.
+func (d *Delaunay) Dest(e EdgeRef) int {
+	return d.corner(e.TIdx, (e.EdgeIdx+2)%3)
+}
+
+// Apex returns the vertex of e's triangle opposite e.
+func (d *Delaunay) Apex(e EdgeRef) int {
+	return d.corner(e.TIdx, e.EdgeIdx)
+}
+
+// corner returns vertex slot k (0=A, 1=B, 2=C) of triangle tIdx.
+func (d *Delaunay) corner(tIdx, k int) int {
+	t := &d.Triangles[tIdx]
+	switch k {
+	case 0:
+		return int(t.A)
+	case 1:
+		return int(t.B)
+	}
+	return int(t.C)
+}
+
+// Star yields the half-edges leaving vertex v, one per triangle around it,
+// rotating counter-clockwise. Around a hull vertex it starts at the
+// clockwise-most triangle. Around a vertex whose fan carving split (see
+// incidence.go) every triangle is checked instead, and the half-edges come
+// in index order.
+func (d *Delaunay) Star(v int) iter.Seq[EdgeRef] {
+	return func(yield func(EdgeRef) bool) {
+		if d.splitFans[v] {
+			for i, t := range d.Triangles {
+				if t.Active && vertexSlot(t, v) != -1 && !yield(d.outgoing(i, v)) {
+					return
+				}
+			}
+			return
+		}
+
+		tIdx := d.incidentTriangle(v)
+		if tIdx == -1 {
+			return
+		}
+		start := d.outgoing(tIdx, v)
+		for e := d.Twin(start); e.TIdx != -1 && e.TIdx != tIdx; e = d.Twin(start) {
+			start = e.Next() // Rotate clockwise to the hull
+		}
+		for e := start; ; {
+			if !yield(e) {
+				return
+			}
+			if e = d.Twin(e.Prev()); e.TIdx == -1 || e.TIdx == start.TIdx {
+				return
+			}
+		}
+	}
+}
+
+// outgoing returns the half-edge of triangle tIdx leaving vertex v.
+func (d *Delaunay) outgoing(tIdx, v int) EdgeRef {
+	return EdgeRef{TIdx: tIdx, EdgeIdx: (vertexSlot(d.Triangles[tIdx], v) + 2) % 3}
+}
+
+// Edges yields every edge of the mesh once: the half-edge on the hull, or
+// of the two twins the one in the lower-numbered triangle.
+func (d *Delaunay) Edges() iter.Seq[EdgeRef] {
+	return func(yield func(EdgeRef) bool) {
+		for i, t := range d.Triangles {
+			if !t.Active {
+				continue
+			}
+			for slot, n := range [3]int32{t.T1, t.T2, t.T3} {
+				if (n == -1 || int(n) > i) && !yield(EdgeRef{TIdx: i, EdgeIdx: slot}) {
+					return
+				}
+			}
+		}
+	}
+}
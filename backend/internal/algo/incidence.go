@@ -0,0 +1,108 @@
+package algo
+
+// The vertex index maps each vertex to an active triangle using it, so a
+// vertex's fan is found without scanning the mesh. Every triangle created or
+// rewritten is recorded with touch, and a triangle is only deactivated when
+// new ones take over its vertices, so an entry is stale only briefly, inside
+// an operation. cleanup and filterTriangles compact the triangles and rebuild
+// the index; a vertex no triangle uses maps to -1.
+//
+// Carving can split a fan where regions meet at a vertex, and a rotation from
+// the index only reaches one piece. filterTriangles flags those vertices in
+// splitFans, as does RemovePoint for a fan it leaves in two, and only their
+// stars fall back to a scan.
+
+// touch records tIdx as the incident triangle of its three vertices.
+func (d *Delaunay) touch(tIdx int) {
+	t := d.Triangles[tIdx]
+	for _, v := range [3]int32{t.A, t.B, t.C} {
+		for int(v) >= len(d.vertexTri) {
+			d.vertexTri = append(d.vertexTri, -1)
+		}
+		d.vertexTri[v] = int32(tIdx)
+	}
+}
+
+// indexVertices rebuilds the vertex index from the active triangles.
+func (d *Delaunay) indexVertices() {
+	d.vertexTri = d.vertexTri[:0]
+	for range d.Points {
+		d.vertexTri = append(d.vertexTri, -1)
+	}
+	for i, t := range d.Triangles {
+		if t.Active {
+			d.touch(i)
+		}
+	}
+}
+
+// reindexVertex repairs the entry for v if its triangle no longer uses it,
+// by scanning the mesh. Only RemovePoint needs it, for a cavity vertex no
+// ear covers.
+func (d *Delaunay) reindexVertex(v int) {
+	if v >= len(d.vertexTri) || d.usesVertex(int(d.vertexTri[v]), v) {
+		return
+	}
+	d.vertexTri[v] = -1
+	for i, t := range d.Triangles {
+		if t.Active && vertexSlot(t, v) != -1 {
+			d.vertexTri[v] = int32(i)
+			return
+		}
+	}
+}
+
+// usesVertex reports whether tIdx is an active triangle using vertex v.
+func (d *Delaunay) usesVertex(tIdx, v int) bool {
+	return tIdx >= 0 && tIdx < len(d.Triangles) && d.Triangles[tIdx].Active && vertexSlot(d.Triangles[tIdx], v) != -1
+}
+
+// incidentTriangle returns an active triangle using vertex v, or -1.
+func (d *Delaunay) incidentTriangle(v int) int {
+	if v < 0 || v >= len(d.vertexTri) {
+		return -1
+	}
+	if tIdx := int(d.vertexTri[v]); d.usesVertex(tIdx, v) {
+		return tIdx
+	}
+	return -1
+}
+
+// incidentTriangles returns the active triangles using vertex v, rotating
+// around its star from the vertex index.
+func (d *Delaunay) incidentTriangles(v int) []int {
+	var around []int
+	for e := range d.Star(v) {
+		around = append(around, e.TIdx)
+	}
+	return around
+}
+
+// markSplitFans flags the vertices whose fan is in more than one piece. Each
+// piece is open, so it has exactly one triangle with a boundary on the
+// clockwise side of the vertex; a vertex with two such triangles is split.
+func (d *Delaunay) markSplitFans() {
+	d.splitFans = nil
+	open := make([]uint8, len(d.Points))
+	for i, t := range d.Triangles {
+		if !t.Active {
+			continue
+		}
+		for _, v := range [3]int32{t.A, t.B, t.C} {
+			if open[v] == 2 || d.Twin(d.outgoing(i, int(v))).TIdx != -1 {
+				continue
+			}
+			if open[v]++; open[v] == 2 {
+				d.flagSplitFan(int(v))
+			}
+		}
+	}
+}
+
+// flagSplitFan makes Star scan the mesh for the triangles around v.
+func (d *Delaunay) flagSplitFan(v int) {
+	if d.splitFans == nil {
+		d.splitFans = make(map[int]bool)
+	}
+	d.splitFans[v] = true
+}
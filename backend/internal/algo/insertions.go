@@ -1,6 +1,166 @@
 package algo
 
-import "math"
+import (
+	"errors"
+	"math"
+)
+
+// InsertPoint adds a point to an existing mesh and returns its vertex index.
+// It works before and after Triangulate: once the super triangle is gone,
+// a point outside the convex hull is joined to every hull edge it can see.
+// A point matching an existing vertex returns that vertex instead.
+// After ClassifyRegions only points inside the remaining triangles are accepted.
+func (d *Delaunay) InsertPoint(p Point) (int, error) {
+	if err := validatePoints([]Point{p}); err != nil {
+		return -1, err
+	}
+
+	// Before Triangulate the input points are not in the mesh yet, so the
+	// triangle p lands in cannot show whether p repeats one of them.
+	if !d.superRemoved {
+		for i, q := range d.Points[:d.superIndices[0]] {
+			if math.Abs(q.X-p.X) <= EPSILON && math.Abs(q.Y-p.Y) <= EPSILON {
+				return i, nil
+			}
+		}
+	}
+
+	tIdx := d.locate(p)
+	if tIdx != -1 {
+		t := d.Triangles[tIdx]
+		for _, v := range [3]int32{t.A, t.B, t.C} {
+			q := d.Points[v]
+			if math.Abs(q.X-p.X) <= EPSILON && math.Abs(q.Y-p.Y) <= EPSILON {
+				return int(v), nil
+			}
+		}
+	}
+
+	switch {
+	case tIdx != -1:
+		d.Points = append(d.Points, p)
+		d.lastCreated = tIdx // Start the walk where locate found it
+		d.insertPoint(len(d.Points) - 1)
+		return len(d.Points) - 1, nil
+	case !d.superRemoved:
+		return -1, errors.New("point lies outside the super triangle")
+	case d.classified:
+		return -1, errors.New("point lies outside the classified mesh")
+	}
+	return d.extendHull(p)
+}
+
+// extendHull adds p, which lies outside the convex hull, by fanning it to
+// the contiguous chain of hull edges visible from p, then legalising the
+// old hull edges.
+func (d *Delaunay) extendHull(p Point) (int, error) {
+	seed := d.visibleHullEdge(p)
+	if seed.TIdx == -1 {
+		return -1, errors.New("no hull edge is visible from point")
+	}
+
+	// Widen the seed to the whole visible chain, in CCW hull order.
+	first := seed
+	for {
+		prev := d.prevHullEdge(first)
+		if prev == seed || !d.hullEdgeVisible(prev, p) {
+			break
+		}
+		first = prev
+	}
+	chain := []EdgeRef{first}
+	for {
+		next := d.nextHullEdge(chain[len(chain)-1])
+		if next == first || !d.hullEdgeVisible(next, p) {
+			break
+		}
+		chain = append(chain, next)
+	}
+
+	d.Points = append(d.Points, p)
+	pIdx := int32(len(d.Points) - 1)
+	fan := make([]int, len(chain))
+	for i := range fan {
+		fan[i] = d.newTriangle()
+	}
+
+	// Edge w_i -> w_{i+1} becomes triangle (w_i, p, w_{i+1}); consecutive
+	// triangles share the spoke p -> w_{i+1}.
+	for i, e := range chain {
+		next, prev := int32(-1), int32(-1)
+		if i < len(chain)-1 {
+			next = int32(fan[i+1])
+		}
+		if i > 0 {
+			prev = int32(fan[i-1])
+		}
+		d.Triangles[fan[i]] = Triangle{
+			A: int32(d.Origin(e)), B: pIdx, C: int32(d.Dest(e)),
+			T1: next, T2: int32(e.TIdx), T3: prev,
+			Active:      true,
+			Constrained: [3]bool{false, d.Triangles[e.TIdx].Constrained[e.EdgeIdx], false},
+		}
+		d.setNeighbor(e.TIdx, e.EdgeIdx, fan[i])
+		d.touch(fan[i])
+	}
+	d.lastCreated = fan[0]
+
+	for i, e := range chain {
+		d.legaliseEdge(fan[i], e.TIdx)
+	}
+	return int(pIdx), nil
+}
+
+// visibleHullEdge finds a hull edge with p strictly on its outer side.
+// The walk towards p stops on such an edge; otherwise all hull edges are scanned.
+func (d *Delaunay) visibleHullEdge(p Point) EdgeRef {
+	if tIdx := d.walkLocate(p, d.lastCreated); tIdx != -1 {
+		for slot := 0; slot < 3; slot++ {
+			if e := (EdgeRef{tIdx, slot}); d.hullEdgeVisible(e, p) {
+				return e
+			}
+		}
+	}
+	for i, t := range d.Triangles {
+		if !t.Active {
+			continue
+		}
+		for slot := 0; slot < 3; slot++ {
+			if e := (EdgeRef{i, slot}); d.hullEdgeVisible(e, p) {
+				return e
+			}
+		}
+	}
+	return EdgeRef{-1, -1}
+}
+
+// hullEdgeVisible reports whether e is a hull edge with p strictly outside it.
+func (d *Delaunay) hullEdgeVisible(e EdgeRef, p Point) bool {
+	if d.getNeighborIdx(e) != -1 {
+		return false
+	}
+	return d.orient2d(d.Points[d.Origin(e)], d.Points[d.Dest(e)], p) < 0
+}
+
+// nextHullEdge returns the hull edge starting where hull edge e ends,
+// found by rotating CCW around the shared vertex.
+func (d *Delaunay) nextHullEdge(e EdgeRef) EdgeRef {
+	out := e.Next() // Edge leaving the shared vertex
+	for twin := d.Twin(out); twin.TIdx != -1; twin = d.Twin(out) {
+		out = twin.Next()
+	}
+	return out
+}
+
+// prevHullEdge returns the hull edge ending where hull edge e starts,
+// found by rotating CW around the shared vertex.
+func (d *Delaunay) prevHullEdge(e EdgeRef) EdgeRef {
+	in := e.Prev() // Edge entering the shared vertex
+	for twin := d.Twin(in); twin.TIdx != -1; twin = d.Twin(in) {
+		in = twin.Prev()
+	}
+	return in
+}
 
 // insertPoint implements Sloan's optimised insertion with edge splitting.
 // See docs/ALGORITHMS.md#14-degeneracy-handling for edge cases.
@@ -30,49 +190,65 @@ func (d *Delaunay) insertPoint(pIdx int) {
 	t := d.Triangles[tIdx]
 	pA, pB, pC := d.Points[int(t.A)], d.Points[int(t.B)], d.Points[int(t.C)]
 
-	if math.Abs(d.orient2d(pA, pB, p)) < EPSILON {
+	if d.orient2d(pA, pB, p) == 0 {
 		d.splitEdge(pIdx, tIdx, int(t.T3), int(t.A), int(t.B), int(t.C))
 		return
 	}
-	if math.Abs(d.orient2d(pB, pC, p)) < EPSILON {
+	if d.orient2d(pB, pC, p) == 0 {
 		d.splitEdge(pIdx, tIdx, int(t.T1), int(t.B), int(t.C), int(t.A))
 		return
 	}
-	if math.Abs(d.orient2d(pC, pA, p)) < EPSILON {
+	if d.orient2d(pC, pA, p) == 0 {
 		d.splitEdge(pIdx, tIdx, int(t.T2), int(t.C), int(t.A), int(t.B))
 		return
 	}
 
 	// 3. Normal case: point inside triangle (1-to-3 split)
-	d.Triangles[tIdx].Active = false
+	d.retire(tIdx)
+	if d.tracer != nil {
+		d.tracer.Trace(TraceEvent{Kind: TraceSplit3, Triangle: tIdx, Neighbor: -1, Vertices: []int32{int32(pIdx), t.A, t.B, t.C}})
+	}
 
 	a, b, c := t.A, t.B, t.C
 	n1, n2, n3 := t.T1, t.T2, t.T3
 
-	newT1Idx := len(d.Triangles)
-	newT2Idx := newT1Idx + 1
-	newT3Idx := newT1Idx + 2
+	// The first new triangle takes over the retired slot
+	newT1Idx := d.newTriangle()
+	newT2Idx := d.newTriangle()
+	newT3Idx := d.newTriangle()
 
 	// T1: BC-P
-	d.Triangles = append(d.Triangles, Triangle{
+	d.Triangles[newT1Idx] = Triangle{
 		A: b, B: c, C: int32(pIdx),
 		T1: int32(newT2Idx), T2: int32(newT3Idx), T3: n1,
-		Active: true,
-	})
+		Active:      true,
+		Constrained: [3]bool{false, false, t.Constrained[0]},
+		Inside:      t.Inside,
+		Depth:       t.Depth,
+	}
 	// T2: CA-P
-	d.Triangles = append(d.Triangles, Triangle{
+	d.Triangles[newT2Idx] = Triangle{
 		A: c, B: a, C: int32(pIdx),
 		T1: int32(newT3Idx), T2: int32(newT1Idx), T3: n2,
-		Active: true,
-	})
+		Active:      true,
+		Constrained: [3]bool{false, false, t.Constrained[1]},
+		Inside:      t.Inside,
+		Depth:       t.Depth,
+	}
 	// T3: AB-P
-	d.Triangles = append(d.Triangles, Triangle{
+	d.Triangles[newT3Idx] = Triangle{
 		A: a, B: b, C: int32(pIdx),
 		T1: int32(newT1Idx), T2: int32(newT2Idx), T3: n3,
-		Active: true,
-	})
+		Active:      true,
+		Constrained: [3]bool{false, false, t.Constrained[2]},
+		Inside:      t.Inside,
+		Depth:       t.Depth,
+	}
 
 	d.lastCreated = newT1Idx
+	d.touch(newT1Idx)
+	d.touch(newT2Idx)
+	d.touch(newT3Idx)
 
 	d.updateNeighbor(int(n1), tIdx, newT1Idx)
 	d.updateNeighbor(int(n2), tIdx, newT2Idx)
@@ -86,105 +262,95 @@ func (d *Delaunay) insertPoint(pIdx int) {
 // splitEdge implements 1-to-4 split for points on shared edges.
 // Detailed algorithm and edge case handling in docs/ALGORITHMS.md#14-degeneracy-handling.
 func (d *Delaunay) splitEdge(pIdx, tIdx, nIdx, u, v, o int) {
-	d.Triangles[tIdx].Active = false
-
-	// Identify neighbours for edges opposite each vertex in triangle T
+	d.retire(tIdx)
+	if d.tracer != nil {
+		d.tracer.Trace(TraceEvent{Kind: TraceSplit4, Triangle: tIdx, Neighbor: nIdx, Vertices: []int32{int32(pIdx), int32(u), int32(v)}})
+	}
 
-	// Re-fetch T to get neighbour indices correctly
+	// T is (u, v, o) in CCW order; its other edges v-o and o-u follow the
+	// split edge u-v
 	t := d.Triangles[tIdx]
-	var n_vo, n_ou int32
-	if int(t.A) == u && int(t.B) == v { // C is o. Edge vo is opp u (T.T1? No T.T1 opp A).
-		// T: A=u, B=v, C=o.
-		// Opp A (u): edge BC (vo) -> T1
-		// Opp B (v): edge CA (ou) -> T2
-		n_vo = t.T1
-		n_ou = t.T2
-	} else if int(t.B) == u && int(t.C) == v { // A is o
-		// T: A=o, B=u, C=v.
-		// Opp B (u): edge CA (vo) -> T2
-		// Opp C (v): edge AB (ou) -> T3
-		n_vo = t.T2
-		n_ou = t.T3
-	} else { // t.C == u && t.A == v. B is o
-		// T: A=v, B=o, C=u
-		// Opp C (u): edge AB (vo) -> T3
-		// Opp A (v): edge BC (ou) -> T1
-		n_vo = t.T3
-		n_ou = t.T1
-	}
+	split := d.outgoing(tIdx, u)
+	vo, ou := split.Next(), split.Prev()
+	n_vo, n_ou := int32(d.getNeighborIdx(vo)), int32(d.getNeighborIdx(ou))
 
 	// Create 2 triangles for T: (p, v, o) and (p, o, u)
 	// T1: (p, v, o). Edges: vo (n_vo), op (newT2), pv (shared with N part 2? No, pv is on split edge)
 	// We need to match orientation.
 	// Create 2 new triangles for T: (p, v, o) and (u, p, o)
-	t1Idx := len(d.Triangles) // (p, v, o)
-	t2Idx := t1Idx + 1        // (u, p, o)
+	t1Idx := d.newTriangle() // (p, v, o)
+	t2Idx := d.newTriangle() // (u, p, o)
 
-	d.Triangles = append(d.Triangles, Triangle{
+	// Both halves of a constrained edge stay constrained
+	constrained := t.Constrained[split.EdgeIdx]
+
+	d.Triangles[t1Idx] = Triangle{
 		A: int32(pIdx), B: int32(v), C: int32(o),
 		T1: n_vo, T2: int32(t2Idx), T3: -1, // T3 will be N's new tri
-		Active: true,
-	})
+		Active:      true,
+		Constrained: [3]bool{t.Constrained[vo.EdgeIdx], false, constrained},
+		Inside:      t.Inside,
+		Depth:       t.Depth,
+	}
 
-	d.Triangles = append(d.Triangles, Triangle{
+	d.Triangles[t2Idx] = Triangle{
 		A: int32(u), B: int32(pIdx), C: int32(o),
 		T1: int32(t1Idx), T2: n_ou, T3: -1, // T3 will be N's new tri
-		Active: true,
-	})
+		Active:      true,
+		Constrained: [3]bool{false, t.Constrained[ou.EdgeIdx], constrained},
+		Inside:      t.Inside,
+		Depth:       t.Depth,
+	}
 
 	d.lastCreated = t1Idx
+	d.touch(t1Idx)
+	d.touch(t2Idx)
 	d.updateNeighbor(int(n_vo), tIdx, t1Idx)
 	d.updateNeighbor(int(n_ou), tIdx, t2Idx)
 
 	// Handle Neighbor N
 	if nIdx != -1 {
-		d.Triangles[nIdx].Active = false
+		d.retire(nIdx)
 		n := d.Triangles[nIdx]
 
-		// Find o_n (opposite vertex in N)
-		// N shares edge v-u (reversed).
-		var o_n int32
-		var n_uo_n, n_o_nv int32
-
-		// Identify o_n and neighbours
-		if int(n.A) == v && int(n.B) == u {
-			o_n = n.C
-			n_uo_n = n.T1 // Opp v: u-o_n
-			n_o_nv = n.T2 // Opp u: o_n-v
-		} else if int(n.B) == v && int(n.C) == u {
-			o_n = n.A
-			n_uo_n = n.T2
-			n_o_nv = n.T3
-		} else { // n.C == v && n.A == u
-			o_n = n.B
-			n_uo_n = n.T3
-			n_o_nv = n.T1
-		}
+		// N shares the edge reversed, as v-u, so it is (v, u, o_n)
+		twin := d.outgoing(nIdx, v)
+		uo_n, o_nv := twin.Next(), twin.Prev()
+		o_n := int32(d.Apex(twin))
+		n_uo_n, n_o_nv := int32(d.getNeighborIdx(uo_n)), int32(d.getNeighborIdx(o_nv))
 
 		// Create 2 triangles for N: (p, u, o_n) and (p, o_n, v)
 		// N was (v, u, o_n) CCW.
 		// Split into (v, p, o_n) and (p, u, o_n).
 
-		n1Idx := len(d.Triangles) // (p, u, o_n)
-		n2Idx := n1Idx + 1        // (v, p, o_n)
+		n1Idx := d.newTriangle() // (p, u, o_n)
+		n2Idx := d.newTriangle() // (v, p, o_n)
 
 		// N1: (p, u, o_n). Edges: u-o_n (n_uo_n), o_n-p (n2Idx), p-u (shared with T2)
-		d.Triangles = append(d.Triangles, Triangle{
+		d.Triangles[n1Idx] = Triangle{
 			A: int32(pIdx), B: int32(u), C: o_n,
 			T1: n_uo_n, T2: int32(n2Idx), T3: int32(t2Idx),
-			Active: true,
-		})
+			Active:      true,
+			Constrained: [3]bool{n.Constrained[uo_n.EdgeIdx], false, constrained},
+			Inside:      n.Inside,
+			Depth:       n.Depth,
+		}
 
 		// N2: (v, p, o_n). Edges: p-o_n (n1Idx), o_n-v (n_o_nv), v-p (shared with T1)
-		d.Triangles = append(d.Triangles, Triangle{
+		d.Triangles[n2Idx] = Triangle{
 			A: int32(v), B: int32(pIdx), C: o_n,
 			T1: int32(n1Idx), T2: n_o_nv, T3: int32(t1Idx),
-			Active: true,
-		})
+			Active:      true,
+			Constrained: [3]bool{false, n.Constrained[o_nv.EdgeIdx], constrained},
+			Inside:      n.Inside,
+			Depth:       n.Depth,
+		}
 
 		// Link back T's undefined neighbours
 		d.Triangles[t1Idx].T3 = int32(n2Idx)
 		d.Triangles[t2Idx].T3 = int32(n1Idx)
+		d.touch(n1Idx)
+		d.touch(n2Idx)
 
 		d.updateNeighbor(int(n_uo_n), nIdx, n1Idx)
 		d.updateNeighbor(int(n_o_nv), nIdx, n2Idx)
@@ -225,24 +391,27 @@ func (d *Delaunay) walkLocate(p Point, startIdx int) int {
 
 		t := d.Triangles[curr]
 		pA, pB, pC := d.Points[int(t.A)], d.Points[int(t.B)], d.Points[int(t.C)]
+		if d.tracer != nil {
+			d.tracer.Trace(TraceEvent{Kind: TraceWalk, Triangle: curr, Neighbor: -1, Vertices: []int32{t.A, t.B, t.C}, Target: p})
+		}
 
 		// Check which edge separates P from the triangle.
 		// Orientation < 0 means P is to the Right (outside).
 		// We walk toward the neighbour opposite that edge.
 
-		if d.orient2d(pB, pC, p) < -EPSILON {
+		if d.orient2d(pB, pC, p) < 0 {
 			// P is right of BC. Move to neighbour T1.
 			if t.T1 == -1 {
 				return curr
 			} // P is outside hull, but this is the closest boundary.
 			curr = int(t.T1)
-		} else if d.orient2d(pC, pA, p) < -EPSILON {
+		} else if d.orient2d(pC, pA, p) < 0 {
 			// P is right of CA. Move to neighbour T2.
 			if t.T2 == -1 {
 				return curr
 			}
 			curr = int(t.T2)
-		} else if d.orient2d(pA, pB, p) < -EPSILON {
+		} else if d.orient2d(pA, pB, p) < 0 {
 			// P is right of AB. Move to neighbour T3.
 			if t.T3 == -1 {
 				return curr
@@ -256,6 +425,21 @@ func (d *Delaunay) walkLocate(p Point, startIdx int) int {
 	return -1
 }
 
+// locate returns the active triangle containing p, or -1 if p lies outside the mesh.
+// The walk can stop on a boundary triangle (or fail on carved meshes), so the
+// result is confirmed with contains before falling back to a linear scan.
+func (d *Delaunay) locate(p Point) int {
+	if tIdx := d.walkLocate(p, d.lastCreated); tIdx != -1 && d.contains(tIdx, p) {
+		return tIdx
+	}
+	for i, t := range d.Triangles {
+		if t.Active && d.contains(i, p) {
+			return i
+		}
+	}
+	return -1
+}
+
 // legaliseEdge performs Lawson's Flip to restore Delaunay property.
 // See docs/ALGORITHMS.md#2-edge-flipping-lawsons-flip
 func (d *Delaunay) legaliseEdge(tIdx, nIdx int) {
@@ -265,22 +449,23 @@ func (d *Delaunay) legaliseEdge(tIdx, nIdx int) {
 
 	n := d.Triangles[nIdx]
 
-	// Identify shared edge and opposite vertex for edge flip check
-	var nSlot int // 0, 1, 2 for A, B, C
-	if int(n.T1) == tIdx {
-		nSlot = 0
-	} else if int(n.T2) == tIdx {
-		nSlot = 1
-	} else {
-		nSlot = 2
+	// The shared edge as a half-edge of N; constrained edges are never flipped
+	shared := EdgeRef{TIdx: nIdx, EdgeIdx: d.neighborSlot(nIdx, tIdx)}
+	if shared.EdgeIdx == -1 || n.Constrained[shared.EdgeIdx] {
+		return
 	}
 
 	// Vertex opposite shared edge in N
-	qIdx := [3]int32{n.A, n.B, n.C}[nSlot]
+	qIdx := int32(d.Apex(shared))
 	q := d.Points[int(qIdx)]
 
 	// Check if edge needs flipping using in-circle test
-	if d.inCircumcircle(tIdx, q) {
+	inside := d.inCircumcircle(tIdx, q)
+	if d.tracer != nil {
+		t := d.Triangles[tIdx]
+		d.tracer.Trace(TraceEvent{Kind: TraceInCircle, Triangle: tIdx, Neighbor: nIdx, Vertices: []int32{t.A, t.B, t.C, qIdx}, Inside: inside})
+	}
+	if inside {
 		d.flipEdge(tIdx, nIdx)
 
 		// Recursive Legalise
@@ -309,25 +494,17 @@ func (d *Delaunay) flipEdge(tIdx, nIdx int) {
 	t := d.Triangles[tIdx]
 	n := d.Triangles[nIdx]
 
-	// Find slots
-	var tSlot int
-	if int(t.T1) == nIdx { tSlot = 0 } else if int(t.T2) == nIdx { tSlot = 1 } else { tSlot = 2 }
-
-	var nSlot int
-	if int(n.T1) == tIdx { nSlot = 0 } else if int(n.T2) == tIdx { nSlot = 1 } else { nSlot = 2 }
-
-	// Vertices
-	// T: (p, u, v) where p is opposite shared edge
-	// N: (q, v, u) where q is opposite shared edge (Order in N is reversed? No, CCW).
-	// Shared edge is u-v.
-	// T has u-v as edge opposite p.
-	// N has v-u as edge opposite q.
-	
-	pIdx := [3]int32{t.A, t.B, t.C}[tSlot]
-	uIdx := [3]int32{t.A, t.B, t.C}[(tSlot+1)%3]
-	vIdx := [3]int32{t.A, t.B, t.C}[(tSlot+2)%3]
-	
-	qIdx := [3]int32{n.A, n.B, n.C}[nSlot]
+	// The shared edge u-v as a half-edge of T, and its twin v-u in N
+	e := EdgeRef{TIdx: tIdx, EdgeIdx: d.neighborSlot(tIdx, nIdx)}
+	twin := d.Twin(e)
+	tSlot, nSlot := e.EdgeIdx, twin.EdgeIdx
+
+	// T: (p, u, v) and N: (q, v, u), with p and q opposite the shared edge
+	pIdx, uIdx, vIdx := int32(d.Apex(e)), int32(d.Origin(e)), int32(d.Dest(e))
+	qIdx := int32(d.Apex(twin))
+	if d.tracer != nil {
+		d.tracer.Trace(TraceEvent{Kind: TraceFlip, Triangle: tIdx, Neighbor: nIdx, Vertices: []int32{uIdx, vIdx, pIdx, qIdx}})
+	}
 
 	// Neighbors
 	nT1 := [3]int32{n.T1, n.T2, n.T3}[(nSlot+1)%3] // Neighbor opp v in N
@@ -351,6 +528,13 @@ func (d *Delaunay) flipEdge(tIdx, nIdx int) {
 	d.Triangles[nIdx].T2 = tT2
 	d.Triangles[nIdx].T3 = int32(tIdx)
 
+	// Outer edges keep their constraint flags; the new diagonal is free
+	d.Triangles[tIdx].Constrained = [3]bool{n.Constrained[(nSlot+1)%3], false, t.Constrained[(tSlot+2)%3]}
+	d.Triangles[nIdx].Constrained = [3]bool{n.Constrained[(nSlot+2)%3], t.Constrained[(tSlot+1)%3], false}
+
+	d.touch(tIdx)
+	d.touch(nIdx)
+
 	// Update outer pointers
 	d.updateNeighbor(int(nT1), nIdx, tIdx)
 	d.updateNeighbor(int(tT2), tIdx, nIdx)
@@ -373,4 +557,18 @@ func (d *Delaunay) updateNeighbor(tIdx, oldN, newN int) {
 		t.T3 = int32(newN)
 		return
 	}
-}
\ No newline at end of file
+}
+// neighborSlot returns which edge slot (0=BC, 1=CA, 2=AB) of tIdx borders nIdx, or -1.
+func (d *Delaunay) neighborSlot(tIdx, nIdx int) int {
+	t := d.Triangles[tIdx]
+	if int(t.T1) == nIdx {
+		return 0
+	}
+	if int(t.T2) == nIdx {
+		return 1
+	}
+	if int(t.T3) == nIdx {
+		return 2
+	}
+	return -1
+}
@@ -0,0 +1,148 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// InsertionOrder is the order Triangulate inserts the points in. Orders that
+// keep consecutive points close together keep walkLocate's walks short.
+// See docs/ALGORITHMS.md#15-insertion-order
+type InsertionOrder int
+
+const (
+	// OrderSortX sorts by X (Sloan's unidimensional binning). It is cheap,
+	// but tall or clustered maps make for long walks between neighbours in
+	// the order.
+	OrderSortX InsertionOrder = iota
+	// OrderHilbert sorts along a Hilbert curve over the bounding box, so
+	// consecutive points are close in both axes.
+	OrderHilbert
+	// OrderBRIO is a biased randomised insertion order: a seeded shuffle cut
+	// into rounds that each double the points inserted so far, each round
+	// sorted along the Hilbert curve. The randomness bounds the expected
+	// flips per insertion whatever the input.
+	OrderBRIO
+)
+
+// Option configures NewDelaunay.
+type Option func(*options)
+
+type options struct {
+	order InsertionOrder
+	seed  int64
+}
+
+// WithInsertionOrder selects the order points are inserted in; the default
+// is OrderSortX.
+func WithInsertionOrder(order InsertionOrder) Option {
+	return func(o *options) {
+		o.order = order
+	}
+}
+
+// WithSeed seeds the OrderBRIO shuffle; the same seed and points give the
+// same mesh. The default seed is 1.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// orderPoints returns the insertion order of points, as indices into it.
+func orderPoints(points []Point, o options) []int {
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+
+	switch o.order {
+	case OrderHilbert:
+		sortHilbert(order, points, hilbertFrame(points))
+	case OrderBRIO:
+		frame := hilbertFrame(points)
+		rng := rand.New(rand.NewSource(o.seed))
+		rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		// Round k is order[n/2^(k+1) : n/2^k], so every round is a random
+		// sample as large as all the rounds before it
+		for hi := len(order); hi > 0; hi /= 2 {
+			sortHilbert(order[hi/2:hi], points, frame)
+		}
+	default:
+		// OPTIMIZATION: "Unidimensional Sorting" approximates spatial binning.
+		// Sorting by X-coordinate improves walking search locality, keeping runtime
+		// closer to O(N^5/4) without implementing full binning.
+		// See docs/ALGORITHMS.md#13-deviations-from-sloans-1987-algorithm
+		sort.Slice(order, func(i, j int) bool { return points[order[i]].X < points[order[j]].X })
+	}
+	return order
+}
+
+// hilbertOrder is the number of bits per axis of the Hilbert grid.
+const hilbertOrder = 16
+
+// frame maps points onto the Hilbert grid: a square over the bounding box,
+// so the curve's locality holds in both axes whatever the aspect ratio.
+type frame struct {
+	minX, minY, scale float64
+}
+
+func hilbertFrame(points []Point) frame {
+	minX, minY := math.MaxFloat64, math.MaxFloat64
+	maxX, maxY := -math.MaxFloat64, -math.MaxFloat64
+	for _, p := range points {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	f := frame{minX: minX, minY: minY}
+	if span := math.Max(maxX-minX, maxY-minY); span > 0 {
+		f.scale = float64(1<<hilbertOrder-1) / span
+	}
+	return f
+}
+
+// sortHilbert sorts indices into points by the position of their point
+// along the Hilbert curve.
+func sortHilbert(order []int, points []Point, f frame) {
+	type keyed struct {
+		key uint64
+		idx int
+	}
+	keys := make([]keyed, len(order))
+	for i, idx := range order {
+		p := points[idx]
+		x := uint32((p.X - f.minX) * f.scale)
+		y := uint32((p.Y - f.minY) * f.scale)
+		keys[i] = keyed{hilbertIndex(x, y), idx}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key < keys[j].key })
+	for i, k := range keys {
+		order[i] = k.idx
+	}
+}
+
+// hilbertIndex returns the distance of cell (x, y) along the Hilbert curve
+// filling the 2^hilbertOrder grid.
+func hilbertIndex(x, y uint32) uint64 {
+	const n = 1 << hilbertOrder
+	var d uint64
+	for s := uint32(n / 2); s > 0; s /= 2 {
+		var rx, ry uint32
+		if x&s != 0 {
+			rx = 1
+		}
+		if y&s != 0 {
+			ry = 1
+		}
+		d += uint64(s) * uint64(s) * uint64((3*rx)^ry)
+		// Rotate the quadrant so the curve inside it runs the same way
+		if ry == 0 {
+			if rx == 1 {
+				x, y = n-1-x, n-1-y
+			}
+			x, y = y, x
+		}
+	}
+	return d
+}
@@ -0,0 +1,174 @@
+package algo
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+)
+
+var (
+	ErrStartBlocked = errors.New("start point is not inside the mesh")
+	ErrGoalBlocked  = errors.New("goal point is not inside the mesh")
+	ErrNoPath       = errors.New("no path between start and goal")
+)
+
+// FindPath runs A* over the Voronoi graph produced by ExportGraph.
+// Start and goal are attached to the triangles containing them and the path
+// follows circumcentres between the two. Constrained edges are never crossed.
+// See docs/ALGORITHMS.md#4-pathfinding-heuristics-dynamic-fusion
+func (d *Delaunay) FindPath(start, goal Point) (*Path, error) {
+	return d.FindPathWithRadius(start, goal, 0)
+}
+
+// FindPathWithRadius is FindPath for a circular agent of the given radius.
+// A triangle is only traversed between two of its edges if its width for that
+// pair (see triangleWidth) fits the agent, so the search state is the
+// triangle together with the edge it was entered through.
+func (d *Delaunay) FindPathWithRadius(start, goal Point, radius float64) (*Path, error) {
+	startTri := d.locate(start)
+	if startTri == -1 || d.pointClearance(startTri, start, radius) < radius {
+		return nil, ErrStartBlocked
+	}
+	goalTri := d.locate(goal)
+	if goalTri == -1 || d.pointClearance(goalTri, goal, radius) < radius {
+		return nil, ErrGoalBlocked
+	}
+
+	// Same triangle: triangles are convex, so the straight segment is valid.
+	if startTri == goalTri {
+		return &Path{
+			Waypoints: []Point{start, goal},
+			Triangles: []int{startTri},
+			Cost:      dist(start, goal),
+			Radius:    radius,
+		}, nil
+	}
+
+	graph := d.ExportGraph()
+	startNode, ok := graph[startTri]
+	if !ok {
+		return nil, ErrStartBlocked
+	}
+	goalNode, ok := graph[goalTri]
+	if !ok {
+		return nil, ErrGoalBlocked
+	}
+
+	// Straight-line distance to the goal never overestimates the remaining
+	// cost, since every edge cost is itself a straight-line distance.
+	h := func(n *GraphNode) float64 {
+		return math.Hypot(goal.X-n.X, goal.Y-n.Y)
+	}
+
+	// A state is tIdx*4 + entry slot; noEntry marks the start triangle.
+	const noEntry = 3
+	initial := startTri*4 + noEntry
+
+	gScore := map[int]float64{initial: math.Hypot(startNode.X-start.X, startNode.Y-start.Y)}
+	cameFrom := make(map[int]int)
+	closed := make(map[int]bool)
+
+	open := &nodeQueue{}
+	heap.Push(open, &queueItem{id: initial, priority: gScore[initial] + h(startNode)})
+
+	var walls []bool
+	if radius > 0 {
+		walls = d.wallVertices()
+	}
+
+	final := -1
+	for open.Len() > 0 {
+		state := heap.Pop(open).(*queueItem).id
+		if closed[state] {
+			continue // Stale queue entry
+		}
+		closed[state] = true
+
+		curr, entry := state/4, state%4
+		if curr == goalTri {
+			final = state
+			break
+		}
+
+		node := graph[curr]
+		for i, nIdx := range node.Neighbors {
+			exit := d.neighborSlot(curr, nIdx)
+			if exit == entry {
+				continue // Turning back never shortens a path
+			}
+			if radius > 0 && entry != noEntry && d.triangleWidth(curr, entry, exit, walls, 2*radius) < 2*radius {
+				continue // Channel through curr is too narrow for the agent
+			}
+
+			next := nIdx*4 + d.neighborSlot(nIdx, curr)
+			if closed[next] {
+				continue
+			}
+			tentative := gScore[state] + node.Costs[i]
+			if g, seen := gScore[next]; seen && tentative >= g {
+				continue
+			}
+			gScore[next] = tentative
+			cameFrom[next] = state
+			heap.Push(open, &queueItem{id: next, priority: tentative + h(graph[nIdx])})
+		}
+	}
+
+	if final == -1 {
+		return nil, ErrNoPath
+	}
+
+	// Rebuild corridor goal -> start, then reverse.
+	corridor := []int{goalTri}
+	for state := final; state != initial; {
+		state = cameFrom[state]
+		corridor = append(corridor, state/4)
+	}
+	for i, j := 0, len(corridor)-1; i < j; i, j = i+1, j-1 {
+		corridor[i], corridor[j] = corridor[j], corridor[i]
+	}
+
+	waypoints := make([]Point, 0, len(corridor)+2)
+	waypoints = append(waypoints, start)
+	for _, tIdx := range corridor {
+		waypoints = append(waypoints, Point{graph[tIdx].X, graph[tIdx].Y})
+	}
+	waypoints = append(waypoints, goal)
+
+	return &Path{
+		Waypoints: waypoints,
+		Triangles: corridor,
+		Cost:      gScore[final] + math.Hypot(goal.X-goalNode.X, goal.Y-goalNode.Y),
+		Radius:    radius,
+	}, nil
+}
+
+func dist(a, b Point) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
+
+// queueItem is an open-set entry. Duplicates are allowed; stale ones are
+// skipped when popped (lazy deletion) instead of using decrease-key.
+type queueItem struct {
+	id       int
+	priority float64
+}
+
+// nodeQueue is a min-heap of queueItems ordered by priority.
+type nodeQueue []*queueItem
+
+func (q nodeQueue) Len() int           { return len(q) }
+func (q nodeQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q nodeQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *nodeQueue) Push(x any) {
+	*q = append(*q, x.(*queueItem))
+}
+
+func (q *nodeQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,323 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrRefineLimit is returned by Refine when the Steiner point budget runs out
+// before every triangle meets the quality bounds. The mesh is still valid.
+var ErrRefineLimit = errors.New("refinement stopped at the Steiner point limit")
+
+// MaxRefineAngle is the largest MinAngle Refine accepts; beyond it Ruppert's
+// algorithm is not expected to terminate.
+const MaxRefineAngle = 34.0
+
+// refineResolution is the shortest edge, as a fraction of the mesh extent,
+// that Refine still splits. Small input angles otherwise drive splitting
+// towards the corner until rounding breaks the geometry.
+const refineResolution = 1e-6
+
+// RefineOptions sets the quality bounds for Refine. A zero bound is disabled.
+type RefineOptions struct {
+	MinAngle   float64 // Smallest allowed angle, in degrees
+	MaxArea    float64 // Largest allowed triangle area
+	MaxSteiner int     // Cap on inserted points; 0 allows 10 per existing vertex
+}
+
+// Refine improves triangle quality with Ruppert's algorithm and returns the
+// number of Steiner points inserted.
+//   - A segment (constrained or boundary edge) with a vertex inside its
+//     diametral circle is split at its midpoint; both halves stay constrained.
+//   - A triangle with an angle below MinAngle or an area above MaxArea gets
+//     a vertex at its circumcentre, unless that vertex would encroach a
+//     segment, in which case the segment is split instead.
+//
+// New triangles inherit Inside, so Refine works before or after
+// ClassifyRegions; a classified mesh is compacted again afterwards. Input
+// angles below 60 degrees can keep the mesh from ever meeting MinAngle, so
+// edges shorter than a millionth of the mesh extent are never split and the
+// number of Steiner points is capped.
+// See docs/ALGORITHMS.md#5-delaunay-refinement
+func (d *Delaunay) Refine(opts RefineOptions) (int, error) {
+	return d.RefineContext(context.Background(), opts)
+}
+
+// RefineContext is Refine, but stops soon after ctx is done and returns
+// ctx.Err() with the number of points inserted so far. The mesh is valid
+// but only partly refined.
+func (d *Delaunay) RefineContext(ctx context.Context, opts RefineOptions) (int, error) {
+	if !d.superRemoved {
+		return 0, errors.New("refine needs a triangulated mesh")
+	}
+	if !(opts.MinAngle >= 0 && opts.MinAngle <= MaxRefineAngle) {
+		return 0, fmt.Errorf("minimum angle %v outside [0, %v] degrees", opts.MinAngle, MaxRefineAngle)
+	}
+	if !(opts.MaxArea >= 0) || math.IsInf(opts.MaxArea, 0) {
+		return 0, fmt.Errorf("maximum area %v must be finite and non-negative", opts.MaxArea)
+	}
+
+	limit := opts.MaxSteiner
+	if limit <= 0 {
+		limit = 10 * len(d.Points)
+	}
+	sin := math.Sin(opts.MinAngle * math.Pi / 180)
+	r := &refiner{ctx: ctx, d: d, minSin2: sin * sin, maxArea: opts.MaxArea, limit: limit}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for i, t := range d.Triangles {
+		if !t.Active {
+			continue
+		}
+		for _, v := range [3]int32{t.A, t.B, t.C} {
+			p := d.Points[v]
+			minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+			maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+		}
+		r.triangles = append(r.triangles, i)
+		for slot := 0; slot < 3; slot++ {
+			r.queueIfEncroached(EdgeRef{i, slot})
+		}
+	}
+
+	resolution := refineResolution * math.Max(maxX-minX, maxY-minY)
+	r.minEdge2 = resolution * resolution
+
+	err := r.run()
+	if d.classified {
+		d.filterTriangles()
+	}
+	return r.added, err
+}
+
+// refiner holds the work queues for one Refine call. Segments are queued by
+// their endpoints because splits and flips rewrite triangle slots.
+type refiner struct {
+	ctx       context.Context
+	d         *Delaunay
+	minSin2   float64 // sin² of the minimum angle
+	maxArea   float64
+	minEdge2  float64 // Squared length below which edges are not split
+	limit     int
+	added     int
+	steps     int // Splits attempted, for cancelled
+	segments  [][2]int32
+	triangles []int
+}
+
+func (r *refiner) run() error {
+	for {
+		// Encroached segments always go first, which keeps every
+		// circumcentre on the visible side of the segments.
+		for len(r.segments) > 0 {
+			seg := r.segments[0]
+			r.segments = r.segments[1:]
+			e := r.d.findEdge(int(seg[0]), int(seg[1]))
+			if e.TIdx == -1 || !r.encroached(e) {
+				continue // Already split
+			}
+			if r.added >= r.limit {
+				return ErrRefineLimit
+			}
+			if err := r.cancelled(); err != nil {
+				return err
+			}
+			r.splitSegment(e)
+		}
+
+		if len(r.triangles) == 0 {
+			return nil
+		}
+		tIdx := r.triangles[0]
+		r.triangles = r.triangles[1:]
+		if !r.d.Triangles[tIdx].Active || !r.bad(tIdx) {
+			continue
+		}
+		if err := r.cancelled(); err != nil {
+			return err
+		}
+		if err := r.splitTriangle(tIdx); err != nil {
+			return err
+		}
+	}
+}
+
+// cancelled returns ctx.Err() on every cancelInterval-th call, so the check
+// stays cheap on the path that inserts points.
+func (r *refiner) cancelled() error {
+	r.steps++
+	if r.steps%cancelInterval != 0 {
+		return nil
+	}
+	return r.ctx.Err()
+}
+
+// bad reports whether triangle tIdx breaks the angle or area bound. The
+// smallest angle θ faces the shortest edge l, with sin θ = l / 2R.
+func (r *refiner) bad(tIdx int) bool {
+	d := r.d
+	t := d.Triangles[tIdx]
+	a, b, c := d.Points[t.A], d.Points[t.B], d.Points[t.C]
+	area2 := d.orient2d(a, b, c)
+	if r.maxArea > 0 && area2/2 > r.maxArea {
+		return true
+	}
+	if r.minSin2 == 0 {
+		return false
+	}
+
+	la, lb, lc := distSq(b, c), distSq(c, a), distSq(a, b)
+	shortest := math.Min(la, math.Min(lb, lc))
+	if shortest < r.minEdge2 {
+		return false
+	}
+	// (2R)² = la·lb·lc / area2²
+	return shortest*area2*area2 < r.minSin2*la*lb*lc
+}
+
+// splitTriangle inserts the circumcentre of tIdx, or splits the segments it
+// would encroach instead.
+func (r *refiner) splitTriangle(tIdx int) error {
+	d := r.d
+	c, ok := d.circumcentre(tIdx)
+	if !ok {
+		return nil
+	}
+	d.lastCreated = tIdx
+	cIdx := d.locate(c)
+	if cIdx == -1 {
+		return nil // Only reachable through rounding; leave the triangle be
+	}
+	ct := d.Triangles[cIdx]
+	for _, v := range [3]int32{ct.A, ct.B, ct.C} {
+		q := d.Points[v]
+		if math.Abs(q.X-c.X) <= EPSILON && math.Abs(q.Y-c.Y) <= EPSILON {
+			return nil
+		}
+	}
+
+	if encroached := r.cavitySegments(cIdx, c); len(encroached) > 0 {
+		split := false
+		for _, seg := range encroached {
+			e := d.findEdge(int(seg[0]), int(seg[1]))
+			if e.TIdx == -1 {
+				continue // Split by an earlier entry
+			}
+			if r.added >= r.limit {
+				return ErrRefineLimit
+			}
+			split = r.splitSegment(e) || split
+		}
+		if split {
+			r.triangles = append(r.triangles, tIdx) // Retry; it may still be bad
+		}
+		return nil
+	}
+
+	if r.added >= r.limit {
+		return ErrRefineLimit
+	}
+	d.Points = append(d.Points, c)
+	d.lastCreated = cIdx
+	d.insertPoint(len(d.Points) - 1)
+	r.added++
+	r.queueAround(len(d.Points) - 1)
+	return nil
+}
+
+// splitSegment inserts the midpoint of segment e, unless the halves would
+// be shorter than the resolution. The point is spliced into the edge
+// directly, as the rounded midpoint need not be exactly collinear.
+func (r *refiner) splitSegment(e EdgeRef) bool {
+	d := r.d
+	u, v := d.Origin(e), d.Dest(e)
+	pu, pv := d.Points[u], d.Points[v]
+	if distSq(pu, pv) < 4*r.minEdge2 {
+		return false
+	}
+
+	d.Points = append(d.Points, Point{(pu.X + pv.X) / 2, (pu.Y + pv.Y) / 2})
+	pIdx := len(d.Points) - 1
+	d.splitEdge(pIdx, e.TIdx, d.Twin(e).TIdx, u, v, d.Apex(e))
+	r.added++
+	r.queueAround(pIdx)
+	return true
+}
+
+// queueAround queues the triangles around a new vertex, which are all the
+// triangles its insertion created or flipped, and any segments they encroach.
+func (r *refiner) queueAround(v int) {
+	for e := range r.d.Star(v) {
+		r.triangles = append(r.triangles, e.TIdx)
+		r.queueIfEncroached(e)
+		r.queueIfEncroached(e.Next())
+		r.queueIfEncroached(e.Prev())
+	}
+}
+
+func (r *refiner) queueIfEncroached(e EdgeRef) {
+	if !r.isSegment(e) || !r.encroached(e) {
+		return
+	}
+	r.segments = append(r.segments, [2]int32{int32(r.d.Origin(e)), int32(r.d.Dest(e))})
+}
+
+// cavitySegments returns the segments on the edges of the triangles whose
+// circumcircles hold c, which are the ones c would see once inserted, that c
+// lies in the diametral circle of.
+func (r *refiner) cavitySegments(start int, c Point) [][2]int32 {
+	d := r.d
+	var segments [][2]int32
+	seen := map[int]bool{start: true}
+	stack := []int{start}
+	for len(stack) > 0 {
+		tIdx := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for slot := 0; slot < 3; slot++ {
+			e := EdgeRef{tIdx, slot}
+			if r.isSegment(e) {
+				u, v := d.Origin(e), d.Dest(e)
+				if inDiametralCircle(d.Points[u], d.Points[v], c) {
+					segments = append(segments, [2]int32{int32(u), int32(v)})
+				}
+				continue
+			}
+			if n := d.Twin(e).TIdx; !seen[n] && d.inCircumcircle(n, c) {
+				seen[n] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+	return segments
+}
+
+func (r *refiner) isSegment(e EdgeRef) bool {
+	return r.d.Triangles[e.TIdx].Constrained[e.EdgeIdx] || r.d.Twin(e).TIdx == -1
+}
+
+// encroached reports whether the apex on either side of edge e lies in its
+// diametral circle. In a constrained Delaunay mesh that is the case whenever
+// any vertex visible from the segment does.
+func (r *refiner) encroached(e EdgeRef) bool {
+	d := r.d
+	a, b := d.Points[d.Origin(e)], d.Points[d.Dest(e)]
+	if inDiametralCircle(a, b, d.Points[d.Apex(e)]) {
+		return true
+	}
+	twin := d.Twin(e)
+	return twin.TIdx != -1 && inDiametralCircle(a, b, d.Points[d.Apex(twin)])
+}
+
+// inDiametralCircle reports whether p lies strictly inside the circle with
+// diameter ab, i.e. the angle apb is obtuse.
+func inDiametralCircle(a, b, p Point) bool {
+	return (a.X-p.X)*(b.X-p.X)+(a.Y-p.Y)*(b.Y-p.Y) < 0
+}
+
+func distSq(a, b Point) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
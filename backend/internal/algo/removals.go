@@ -0,0 +1,322 @@
+package algo
+
+import "fmt"
+
+// RemovePoint deletes vertex idx from the mesh and re-triangulates the hole.
+// The star of triangles around the vertex is removed and the cavity polygon
+// is filled by ear clipping, always cutting an ear whose circumcircle holds
+// no other polygon vertex, so the result is the Delaunay triangulation of the
+// cavity. Vertices on the convex hull are handled as an open fan.
+// Endpoints of constrained edges and super triangle vertices are refused.
+// The point stays in d.Points (indices are stable) but is no longer referenced.
+func (d *Delaunay) RemovePoint(idx int) error {
+	if idx < 0 || idx >= len(d.Points) {
+		return fmt.Errorf("vertex %d out of range", idx)
+	}
+	for _, s := range d.superIndices {
+		if idx == s {
+			return fmt.Errorf("vertex %d belongs to the super triangle", idx)
+		}
+	}
+
+	start := d.incidentTriangle(idx)
+	if start == -1 {
+		return fmt.Errorf("vertex %d is not in the mesh", idx)
+	}
+
+	star, closed, err := d.vertexStar(idx, start)
+	if err != nil {
+		return err
+	}
+
+	// Cavity polygon: link vertices CCW, with the triangle and constraint flag
+	// outside each link edge poly[i] -> poly[i+1].
+	poly := make([]int32, 0, len(star)+1)
+	outer := make([]int32, 0, len(star))
+	cons := make([]bool, 0, len(star))
+	for _, tIdx := range star {
+		t := d.Triangles[tIdx]
+		verts := [3]int32{t.A, t.B, t.C}
+		k := vertexSlot(t, idx)
+		poly = append(poly, verts[(k+1)%3])
+		outer = append(outer, [3]int32{t.T1, t.T2, t.T3}[k])
+		cons = append(cons, t.Constrained[k])
+	}
+	if !closed {
+		last := d.Triangles[star[len(star)-1]]
+		poly = append(poly, [3]int32{last.A, last.B, last.C}[(vertexSlot(last, idx)+2)%3])
+	}
+
+	ears, err := d.clipEars(poly, closed)
+	if err != nil {
+		return err
+	}
+
+	inside, depth := d.Triangles[star[0]].Inside, d.Triangles[star[0]].Depth
+
+	// Create the new triangles, then stitch them to each other and to the
+	// triangles outside the cavity by matching edge endpoints. The star is
+	// retired only afterwards, so no ear reuses a slot the stitching still
+	// refers to.
+	created := make([]int, len(ears))
+	for i, e := range ears {
+		created[i] = d.newTriangle()
+		d.Triangles[created[i]] = Triangle{
+			A: e[0], B: e[1], C: e[2],
+			T1: -1, T2: -1, T3: -1,
+			Active: true,
+			Inside: inside,
+			Depth:  depth,
+		}
+		d.touch(created[i])
+	}
+	for _, tIdx := range star {
+		d.retire(tIdx)
+	}
+
+	type edgeKey struct{ u, v int32 }
+	halfEdges := make(map[edgeKey]int, 3*len(created))
+	for _, tIdx := range created {
+		t := d.Triangles[tIdx]
+		verts := [3]int32{t.A, t.B, t.C}
+		for slot := 0; slot < 3; slot++ {
+			halfEdges[edgeKey{verts[(slot+1)%3], verts[(slot+2)%3]}] = tIdx
+		}
+	}
+	for _, tIdx := range created {
+		t := d.Triangles[tIdx]
+		verts := [3]int32{t.A, t.B, t.C}
+		for slot := 0; slot < 3; slot++ {
+			u, v := verts[(slot+1)%3], verts[(slot+2)%3]
+			if nIdx, ok := halfEdges[edgeKey{v, u}]; ok {
+				d.setNeighbor(tIdx, slot, nIdx)
+			}
+		}
+	}
+	for i := range outer {
+		u, v := poly[i], poly[(i+1)%len(poly)]
+		tIdx, ok := halfEdges[edgeKey{u, v}]
+		if !ok {
+			continue // Link edge became a hull edge (open fan only)
+		}
+		slot := edgeSlot(d.Triangles[tIdx], u, v)
+		d.Triangles[tIdx].Constrained[slot] = cons[i]
+		d.setNeighbor(tIdx, slot, int(outer[i]))
+		d.updateNeighbor(int(outer[i]), int(star[i]), tIdx)
+	}
+	// Open fan: link edges not covered by an ear now face the hull.
+	if !closed {
+		for i := range outer {
+			u, v := poly[i], poly[i+1]
+			if _, ok := halfEdges[edgeKey{u, v}]; !ok {
+				d.updateNeighbor(int(outer[i]), int(star[i]), -1)
+			}
+		}
+	}
+
+	// Ears cover the cavity's vertices; on an open fan a vertex no ear
+	// covers keeps only triangles outside the cavity. On a carved mesh those
+	// can lie on both sides of a vertex inside the chain, splitting its fan.
+	d.vertexTri[idx] = -1
+	for i, w := range poly {
+		d.reindexVertex(int(w))
+		if d.classified && !closed && i > 0 && i < len(poly)-1 {
+			d.flagSplitFan(int(w))
+		}
+	}
+
+	if len(created) > 0 {
+		d.lastCreated = created[0]
+	} else {
+		d.lastCreated = d.anyActiveTriangle()
+	}
+
+	// Ears are Delaunay within the cavity; legalising the rim also covers
+	// points beyond it and hull removals.
+	for _, tIdx := range created {
+		t := d.Triangles[tIdx]
+		for _, n := range [3]int32{t.T1, t.T2, t.T3} {
+			if d.Triangles[tIdx].Active {
+				d.legaliseEdge(tIdx, int(n))
+			}
+		}
+	}
+	return nil
+}
+
+// vertexStar returns the triangles around vertex v in CCW order. For an
+// interior vertex the fan is closed; for a hull vertex it is open and starts
+// at the clockwise-most triangle. Constrained spokes are refused, as is a
+// fan carving split (see incidence.go), whose cavity is not one polygon.
+func (d *Delaunay) vertexStar(v, start int) ([]int, bool, error) {
+	// Rotate clockwise to the hull (or all the way round).
+	first := start
+	for {
+		t := d.Triangles[first]
+		k := vertexSlot(t, v)
+		cw := int([3]int32{t.T1, t.T2, t.T3}[(k+2)%3]) // Across edge (prev, v)
+		if cw == -1 || !d.Triangles[cw].Active {
+			break
+		}
+		if cw == start {
+			first = start
+			break
+		}
+		first = cw
+	}
+
+	star := []int{}
+	closed := false
+	for curr := first; ; {
+		t := d.Triangles[curr]
+		k := vertexSlot(t, v)
+		if t.Constrained[(k+1)%3] || t.Constrained[(k+2)%3] {
+			return nil, false, fmt.Errorf("vertex %d is an endpoint of a constrained edge", v)
+		}
+		star = append(star, curr)
+		if len(star) > len(d.Triangles) {
+			return nil, false, fmt.Errorf("star of vertex %d does not close", v)
+		}
+
+		ccw := int([3]int32{t.T1, t.T2, t.T3}[(k+1)%3]) // Across edge (v, next)
+		if ccw == -1 || !d.Triangles[ccw].Active {
+			break
+		}
+		if ccw == first {
+			closed = true
+			break
+		}
+		curr = ccw
+	}
+
+	// The rotation only walks the piece holding start; Star scans a flagged
+	// vertex, so it also finds any other piece
+	if d.splitFans[v] {
+		count := 0
+		for range d.Star(v) {
+			count++
+		}
+		if count != len(star) {
+			return nil, false, fmt.Errorf("fan of vertex %d is split", v)
+		}
+	}
+	return star, closed, nil
+}
+
+// clipEars triangulates the cavity polygon. A closed polygon is clipped down
+// to a single triangle; an open chain (hull vertex removed) is clipped until
+// no convex ear is left, leaving the new hull edges.
+func (d *Delaunay) clipEars(poly []int32, closed bool) ([][3]int32, error) {
+	ring := append([]int32(nil), poly...)
+	ears := make([][3]int32, 0, len(ring))
+
+	for len(ring) >= 3 {
+		n := len(ring)
+		candidates := n
+		if !closed {
+			candidates = n - 2
+		}
+
+		best := -1
+		for i := 0; i < candidates; i++ {
+			a, b, c := ring[i], ring[(i+1)%n], ring[(i+2)%n]
+			if d.orient2d(d.Points[a], d.Points[b], d.Points[c]) <= 0 {
+				continue // Reflex or flat
+			}
+			if d.earIsDelaunay(ring, i) {
+				best = i
+				break
+			}
+			if best == -1 && d.earIsEmpty(ring, i) {
+				best = i // Fallback if cocircular ties leave no strict Delaunay ear
+			}
+		}
+		if best == -1 {
+			if closed {
+				return nil, fmt.Errorf("cavity polygon has no ear (%d vertices left)", n)
+			}
+			break
+		}
+
+		ears = append(ears, [3]int32{ring[best], ring[(best+1)%n], ring[(best+2)%n]})
+		cut := (best + 1) % n
+		ring = append(ring[:cut], ring[cut+1:]...)
+		if closed && len(ring) == 3 {
+			ears = append(ears, [3]int32{ring[0], ring[1], ring[2]})
+			break
+		}
+	}
+	return ears, nil
+}
+
+// earIsDelaunay reports whether no other ring vertex lies strictly inside the
+// circumcircle of the ear starting at ring[i].
+func (d *Delaunay) earIsDelaunay(ring []int32, i int) bool {
+	n := len(ring)
+	a, b, c := d.Points[ring[i]], d.Points[ring[(i+1)%n]], d.Points[ring[(i+2)%n]]
+	for j := 3; j < n; j++ {
+		if inCircle(a, b, c, d.Points[ring[(i+j)%n]]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// earIsEmpty reports whether no other ring vertex lies inside the ear triangle.
+func (d *Delaunay) earIsEmpty(ring []int32, i int) bool {
+	n := len(ring)
+	a, b, c := d.Points[ring[i]], d.Points[ring[(i+1)%n]], d.Points[ring[(i+2)%n]]
+	for j := 3; j < n; j++ {
+		p := d.Points[ring[(i+j)%n]]
+		if d.orient2d(a, b, p) > 0 && d.orient2d(b, c, p) > 0 && d.orient2d(c, a, p) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Delaunay) anyActiveTriangle() int {
+	for i, t := range d.Triangles {
+		if t.Active {
+			return i
+		}
+	}
+	return 0
+}
+
+// setNeighbor sets the neighbour across edge slot of tIdx.
+func (d *Delaunay) setNeighbor(tIdx, slot, nIdx int) {
+	switch slot {
+	case 0:
+		d.Triangles[tIdx].T1 = int32(nIdx)
+	case 1:
+		d.Triangles[tIdx].T2 = int32(nIdx)
+	default:
+		d.Triangles[tIdx].T3 = int32(nIdx)
+	}
+}
+
+// vertexSlot returns 0, 1 or 2 for v being A, B or C of t, or -1.
+func vertexSlot(t Triangle, v int) int {
+	switch v {
+	case int(t.A):
+		return 0
+	case int(t.B):
+		return 1
+	case int(t.C):
+		return 2
+	}
+	return -1
+}
+
+// edgeSlot returns the slot of the edge with endpoints u and v (either order), or -1.
+func edgeSlot(t Triangle, u, v int32) int {
+	verts := [3]int32{t.A, t.B, t.C}
+	for slot := 0; slot < 3; slot++ {
+		a, b := verts[(slot+1)%3], verts[(slot+2)%3]
+		if (a == u && b == v) || (a == v && b == u) {
+			return slot
+		}
+	}
+	return -1
+}
@@ -0,0 +1,87 @@
+package algo
+
+// Triangle slots are never moved while a mesh is being edited, so indices
+// held across an edit stay valid unless the triangle itself is replaced.
+// A replaced triangle is retired: marked inactive and its slot put on the
+// free list, where the next new triangle picks it up. cleanup,
+// filterTriangles and Compact close the gaps left in between.
+
+// newTriangle returns a slot for a new triangle, reusing a retired one if
+// there is one. The caller fills it in.
+func (d *Delaunay) newTriangle() int {
+	if n := len(d.free); n > 0 {
+		tIdx := int(d.free[n-1])
+		d.free = d.free[:n-1]
+		return tIdx
+	}
+	d.Triangles = append(d.Triangles, Triangle{})
+	return len(d.Triangles) - 1
+}
+
+// retire deactivates the active triangle tIdx and frees its slot. The slot
+// keeps its contents until it is reused, so the caller may still read them.
+func (d *Delaunay) retire(tIdx int) {
+	d.Triangles[tIdx].Active = false
+	d.free = append(d.free, int32(tIdx))
+}
+
+// TriangleCount returns the number of active triangles, leaving out the
+// retired slots still waiting on the free list.
+func (d *Delaunay) TriangleCount() int {
+	count := 0
+	for _, t := range d.Triangles {
+		if t.Active {
+			count++
+		}
+	}
+	return count
+}
+
+// Compact drops the inactive triangle slots, renumbering the active
+// triangles in order. It returns the new index of every old slot, -1 for
+// the dropped ones, so callers holding triangle indices can remap them.
+func (d *Delaunay) Compact() []int {
+	newIndices := d.compact(func(Triangle) bool { return true })
+	mapping := make([]int, len(newIndices))
+	for i, n := range newIndices {
+		mapping[i] = int(n)
+	}
+	return mapping
+}
+
+// compact keeps the active triangles that keep accepts, in order, and
+// returns the new index of each old slot (-1 if dropped). Neighbour links to
+// dropped triangles become boundaries (-1).
+func (d *Delaunay) compact(keep func(t Triangle) bool) []int32 {
+	newIndices := make([]int32, len(d.Triangles))
+	activeCount := 0
+	for i, t := range d.Triangles {
+		newIndices[i] = -1
+		if t.Active && keep(t) {
+			newIndices[i] = int32(activeCount)
+			activeCount++
+		}
+	}
+
+	updateN := func(n int32) int32 {
+		if n == -1 {
+			return -1
+		}
+		return newIndices[n]
+	}
+	newTriangles := make([]Triangle, 0, activeCount)
+	for i, t := range d.Triangles {
+		if newIndices[i] != -1 {
+			t.T1 = updateN(t.T1)
+			t.T2 = updateN(t.T2)
+			t.T3 = updateN(t.T3)
+			newTriangles = append(newTriangles, t)
+		}
+	}
+
+	d.Triangles = newTriangles
+	d.free = d.free[:0]
+	d.lastCreated = remapLastCreated(d.lastCreated, newIndices)
+	d.indexVertices()
+	return newIndices
+}
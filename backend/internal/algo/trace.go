@@ -0,0 +1,63 @@
+package algo
+
+import "fmt"
+
+// TraceKind names the algorithm step a TraceEvent records.
+type TraceKind int
+
+const (
+	TraceWalk     TraceKind = iota // walkLocate visited a triangle; Vertices are its corners
+	TraceSplit3                    // A point split a triangle in three; Vertices are p, a, b, c
+	TraceSplit4                    // A point split edge u-v; Vertices are p, u, v
+	TraceInCircle                  // legaliseEdge tested q against a circumcircle; Vertices are a, b, c, q
+	TraceFlip                      // flipEdge replaced diagonal u-v with p-q; Vertices are u, v, p, q
+)
+
+func (k TraceKind) String() string {
+	switch k {
+	case TraceWalk:
+		return "walk"
+	case TraceSplit3:
+		return "split3"
+	case TraceSplit4:
+		return "split4"
+	case TraceInCircle:
+		return "incircle"
+	case TraceFlip:
+		return "flip"
+	}
+	return fmt.Sprintf("TraceKind(%d)", int(k))
+}
+
+// TraceEvent is one step of the algorithm. Vertices are indices into
+// Points, so the event stays meaningful after the triangles it names are
+// rewritten by later flips.
+type TraceEvent struct {
+	Kind     TraceKind
+	Triangle int     // Triangle visited, split, tested or flipped
+	Neighbor int     // Other triangle of an edge split, test or flip; -1 if none
+	Vertices []int32 // See TraceKind
+	Target   Point   // Point being located, for TraceWalk
+	Inside   bool    // TraceInCircle result: q is inside, so the edge flips
+}
+
+// Tracer receives each step of the algorithm as it happens.
+type Tracer interface {
+	Trace(e TraceEvent)
+}
+
+// TraceRecorder is a Tracer that keeps every event, for TraceJSON.
+type TraceRecorder struct {
+	Events []TraceEvent
+}
+
+func (r *TraceRecorder) Trace(e TraceEvent) {
+	r.Events = append(r.Events, e)
+}
+
+// SetTracer installs t to receive the steps of every later insertion,
+// location and flip; nil turns tracing off. Events are only built while a
+// tracer is set, so an untraced mesh pays a nil check per step.
+func (d *Delaunay) SetTracer(t Tracer) {
+	d.tracer = t
+}
@@ -17,6 +17,7 @@ type Triangle struct {
 	Active     bool  // Logical deletion
 	Constrained [3]bool // Bitmask or bools: is edge i constrained?
 	Inside      bool    // Part of the constrained interior
+	Depth       int32   // Nesting depth: constrained edges crossed from outside the hull
 }
 
 
@@ -30,6 +31,13 @@ type Delaunay struct {
 	Triangles    []Triangle
 	superIndices [3]int
 	lastCreated  int // Cache for Sloan's Walking Search
+	superRemoved bool // cleanup has run; the mesh covers only the convex hull
+	classified   bool // ClassifyRegions has run; outside triangles are gone
+	tracer       Tracer // Receives algorithm steps when set
+	vertexTri    []int32 // An active triangle using each vertex, -1 if none (see incidence.go)
+	splitFans    map[int]bool // Vertices whose fan carving split (see incidence.go)
+	free         []int32 // Retired triangle slots to reuse (see slots.go)
+	inputVertex  []int32 // Vertex of each point passed to NewDelaunay
 }
 
 // GraphNode represents a Voronoi vertex for pathfinding.
@@ -41,3 +49,24 @@ type GraphNode struct {
 	Neighbors []int
 	Costs     []float64 // Edge costs for A* pathfinding
 }
+
+// IndexedMesh is the active triangulation as shared buffers. Triangle i uses
+// Triangles[3i:3i+3]; Neighbors and Constrained use the same slots as
+// Triangle, so slot k is the neighbour and edge opposite the k-th vertex.
+type IndexedMesh struct {
+	Vertices    []Point
+	Triangles   []int32
+	Neighbors   []int32 // -1 on the boundary
+	Constrained []bool
+	Sources     []int // Index into Points of each vertex
+}
+
+// Path is the result of a search over the navigation graph.
+// Waypoints run start -> circumcentres -> goal; Triangles is the corridor
+// of triangle indices traversed, in order.
+type Path struct {
+	Waypoints []Point
+	Triangles []int
+	Cost      float64
+	Radius    float64 // Agent radius the corridor was planned for
+}
@@ -0,0 +1,214 @@
+package algo
+
+import "fmt"
+
+// ViolationKind names the mesh invariant a Violation breaks.
+type ViolationKind int
+
+const (
+	ViolationOrientation ViolationKind = iota // Triangle is not strictly CCW
+	ViolationNeighbor                         // T1/T2/T3 link is dangling or one-way
+	ViolationConstraint                       // Constrained flags differ across an edge
+	ViolationDelaunay                         // Unconstrained edge is not locally Delaunay
+	ViolationEuler                            // V - E + F does not match the mesh topology
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case ViolationOrientation:
+		return "orientation"
+	case ViolationNeighbor:
+		return "neighbour"
+	case ViolationConstraint:
+		return "constraint"
+	case ViolationDelaunay:
+		return "delaunay"
+	case ViolationEuler:
+		return "euler"
+	}
+	return fmt.Sprintf("ViolationKind(%d)", int(k))
+}
+
+// Violation is one broken invariant found by Validate.
+// Triangle and Neighbor are -1 when they do not apply.
+type Violation struct {
+	Kind     ViolationKind
+	Triangle int
+	Neighbor int
+	Message  string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Kind, v.Message)
+}
+
+// Validate checks the active triangles and returns every violation found;
+// an empty result means the mesh is sound.
+//   - every triangle is strictly counter-clockwise
+//   - neighbour links point back and share the edge
+//   - constrained flags agree on both sides of an edge
+//   - every unconstrained edge is locally Delaunay, which (Delaunay's lemma)
+//     means no point is inside the circumcircle of a triangle it can see
+//   - the Euler characteristic matches the components and boundary loops
+func (d *Delaunay) Validate() []Violation {
+	var violations []Violation
+	report := func(kind ViolationKind, tIdx, nIdx int, format string, args ...any) {
+		violations = append(violations, Violation{kind, tIdx, nIdx, fmt.Sprintf(format, args...)})
+	}
+
+	linksOK := true
+	for i, t := range d.Triangles {
+		if !t.Active {
+			continue
+		}
+		verts := [3]int32{t.A, t.B, t.C}
+		inRange := true
+		for _, v := range verts {
+			if v < 0 || int(v) >= len(d.Points) {
+				report(ViolationOrientation, i, -1, "triangle %d uses vertex %d out of range", i, v)
+				inRange = false
+			}
+		}
+		if !inRange {
+			linksOK = false
+			continue
+		}
+		a, b, c := d.Points[t.A], d.Points[t.B], d.Points[t.C]
+		if d.orient2d(a, b, c) <= 0 {
+			report(ViolationOrientation, i, -1, "triangle %d (%d, %d, %d) is not counter-clockwise", i, t.A, t.B, t.C)
+		}
+
+		for slot, n := range [3]int32{t.T1, t.T2, t.T3} {
+			if n == -1 {
+				continue
+			}
+			nIdx := int(n)
+			u, v := verts[(slot+1)%3], verts[(slot+2)%3]
+			if nIdx < 0 || nIdx >= len(d.Triangles) || !d.Triangles[nIdx].Active {
+				report(ViolationNeighbor, i, nIdx, "triangle %d links to missing or inactive triangle %d", i, nIdx)
+				linksOK = false
+				continue
+			}
+			nt := d.Triangles[nIdx]
+			nSlot := d.neighborSlot(nIdx, i)
+			if nSlot == -1 {
+				report(ViolationNeighbor, i, nIdx, "triangle %d links to %d but not back", i, nIdx)
+				linksOK = false
+				continue
+			}
+			// Consistently oriented neighbours run the shared edge in opposite directions.
+			nVerts := [3]int32{nt.A, nt.B, nt.C}
+			if nVerts[(nSlot+1)%3] != v || nVerts[(nSlot+2)%3] != u {
+				report(ViolationNeighbor, i, nIdx, "triangles %d and %d are linked but do not share edge %d-%d", i, nIdx, u, v)
+				linksOK = false
+				continue
+			}
+			if nIdx < i {
+				continue // Edge checks below run once per edge
+			}
+
+			if t.Constrained[slot] != nt.Constrained[nSlot] {
+				report(ViolationConstraint, i, nIdx, "edge %d-%d is constrained on one side only", u, v)
+			}
+			q := d.Points[nVerts[nSlot]]
+			if !t.Constrained[slot] && !nt.Constrained[nSlot] && inCircle(a, b, c, q) > 0 {
+				report(ViolationDelaunay, i, nIdx, "edge %d-%d between triangles %d and %d is not locally Delaunay", u, v, i, nIdx)
+			}
+		}
+	}
+
+	// The boundary walk assumes sound links.
+	if linksOK {
+		if msg := d.checkEuler(); msg != "" {
+			report(ViolationEuler, -1, -1, "%s", msg)
+		}
+	}
+	return violations
+}
+
+// checkEuler compares V - E + F against 2C - B, where C is the number of
+// connected components and B the number of boundary loops (one per component
+// plus one per hole). Vertices are counted once per fan of triangles, so a
+// vertex pinched between two regions counts twice.
+func (d *Delaunay) checkEuler() string {
+	faces, halfEdges, boundary := 0, 0, 0
+	used := make(map[int32]bool)
+	openFans := make(map[int32]int)
+	for _, t := range d.Triangles {
+		if !t.Active {
+			continue
+		}
+		faces++
+		verts := [3]int32{t.A, t.B, t.C}
+		for slot, n := range [3]int32{t.T1, t.T2, t.T3} {
+			used[verts[slot]] = true
+			halfEdges++
+			if n == -1 {
+				boundary++
+				// Each open fan has exactly one boundary edge leaving its vertex.
+				openFans[verts[(slot+1)%3]]++
+			}
+		}
+	}
+	if faces == 0 {
+		return ""
+	}
+
+	vertices := 0
+	for v := range used {
+		if n := openFans[v]; n > 0 {
+			vertices += n
+		} else {
+			vertices++
+		}
+	}
+	edges := (halfEdges-boundary)/2 + boundary
+
+	components := 0
+	seen := make([]bool, len(d.Triangles))
+	for i, t := range d.Triangles {
+		if !t.Active || seen[i] {
+			continue
+		}
+		components++
+		stack := []int{i}
+		seen[i] = true
+		for len(stack) > 0 {
+			curr := d.Triangles[stack[len(stack)-1]]
+			stack = stack[:len(stack)-1]
+			for _, n := range [3]int32{curr.T1, curr.T2, curr.T3} {
+				if n != -1 && !seen[n] {
+					seen[n] = true
+					stack = append(stack, int(n))
+				}
+			}
+		}
+	}
+
+	loops := 0
+	walked := make(map[EdgeRef]bool)
+	for i, t := range d.Triangles {
+		if !t.Active {
+			continue
+		}
+		for slot, n := range [3]int32{t.T1, t.T2, t.T3} {
+			start := EdgeRef{i, slot}
+			if n != -1 || walked[start] {
+				continue
+			}
+			loops++
+			for e := start; !walked[e]; e = d.nextHullEdge(e) {
+				walked[e] = true
+				if len(walked) > boundary {
+					return "boundary edges do not form closed loops"
+				}
+			}
+		}
+	}
+
+	if chi, want := vertices-edges+faces, 2*components-loops; chi != want {
+		return fmt.Sprintf("V - E + F = %d - %d + %d = %d, want 2C - B = 2*%d - %d = %d",
+			vertices, edges, faces, chi, components, loops, want)
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/ORBWARRIOR/PolyNav/backend/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+const fileExt = ".map.pb"
+
+// validID matches the IDs FileStore generates, so an ID can never name a
+// file outside the store directory.
+var validID = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// FileStore is a Repository keeping one protobuf file per map in a
+// directory. Writes go to a temporary file that is renamed into place, so a
+// crash never leaves a half-written map.
+type FileStore struct {
+	dir string
+	mu  sync.RWMutex
+	now func() time.Time
+}
+
+// NewFileStore opens the store in dir, creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create map directory: %w", err)
+	}
+	return &FileStore{dir: dir, now: time.Now}, nil
+}
+
+func (s *FileStore) Save(ctx context.Context, m *pb.StoredMap) (*pb.StoredMap, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := proto.Clone(m).(*pb.StoredMap)
+	now := s.now().UnixMilli()
+	if stored.MapId == "" {
+		id, err := newID()
+		if err != nil {
+			return nil, err
+		}
+		stored.MapId = id
+		stored.CreatedAt = now
+	} else {
+		existing, err := s.read(stored.MapId)
+		if err != nil {
+			return nil, err
+		}
+		stored.CreatedAt = existing.CreatedAt
+	}
+	stored.UpdatedAt = now
+
+	data, err := proto.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode map: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, "save-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to save map: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to save map: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to save map: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(stored.MapId)); err != nil {
+		return nil, fmt.Errorf("failed to save map: %w", err)
+	}
+	return stored, nil
+}
+
+func (s *FileStore) Load(ctx context.Context, id string) (*pb.StoredMap, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.read(id)
+}
+
+// List summarises the stored maps, most recently updated first. Files that
+// cannot be read or decoded are logged and left out.
+func (s *FileStore) List(ctx context.Context) ([]*pb.MapSummary, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maps: %w", err)
+	}
+	var summaries []*pb.MapSummary
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		id, ok := strings.CutSuffix(e.Name(), fileExt)
+		if !ok || !validID.MatchString(id) {
+			continue
+		}
+		m, err := s.read(id)
+		if err != nil {
+			// One bad file must not hide every other map
+			log.Warn().Err(err).Str("map_id", id).Msg("Skipping unreadable map")
+			continue
+		}
+		summaries = append(summaries, Summarise(m))
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].UpdatedAt > summaries[j].UpdatedAt
+	})
+	return summaries, nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !validID.MatchString(id) {
+		return ErrNotFound
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// read loads map id; the caller holds the lock.
+func (s *FileStore) read(id string) (*pb.StoredMap, error) {
+	if !validID.MatchString(id) {
+		return nil, ErrNotFound
+	}
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read map %s: %w", id, err)
+	}
+	m := &pb.StoredMap{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to decode map %s: %w", id, err)
+	}
+	return m, nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+fileExt)
+}
+
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate map ID: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/ORBWARRIOR/PolyNav/backend/pkg/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func testMap(name string) *pb.StoredMap {
+	return &pb.StoredMap{
+		Name: name,
+		Map: &pb.MapData{
+			Obstacles: []*pb.Obstacle{{Points: []*pb.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}}},
+			Start:     &pb.Point{X: 2, Y: 2},
+		},
+	}
+}
+
+// newTestStore returns a store whose clock advances a second per call.
+func newTestStore(t *testing.T, dir string) *FileStore {
+	t.Helper()
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	clock := time.Unix(1000, 0)
+	s.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	return s
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newTestStore(t, dir)
+
+	first, err := s.Save(ctx, testMap("first"))
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if !validID.MatchString(first.MapId) {
+		t.Errorf("Expected a generated ID, got %q", first.MapId)
+	}
+	if first.CreatedAt == 0 || first.CreatedAt != first.UpdatedAt {
+		t.Errorf("Expected matching creation and update times, got %d and %d", first.CreatedAt, first.UpdatedAt)
+	}
+	second, err := s.Save(ctx, testMap("second"))
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Overwriting keeps the ID and creation time.
+	update := testMap("first, renamed")
+	update.MapId = first.MapId
+	updated, err := s.Save(ctx, update)
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if updated.CreatedAt != first.CreatedAt || updated.UpdatedAt <= second.UpdatedAt {
+		t.Errorf("Expected created %d and updated after %d, got %d and %d",
+			first.CreatedAt, second.UpdatedAt, updated.CreatedAt, updated.UpdatedAt)
+	}
+
+	// A new store on the same directory sees the same maps.
+	reopened := newTestStore(t, dir)
+	loaded, err := reopened.Load(ctx, first.MapId)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !proto.Equal(loaded, updated) {
+		t.Errorf("Loaded %v, want %v", loaded, updated)
+	}
+
+	list, err := reopened.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 2 || list[0].MapId != first.MapId || list[1].MapId != second.MapId {
+		t.Fatalf("Expected the updated map listed first, got %v", list)
+	}
+	if list[0].Name != "first, renamed" || list[0].ObstacleCount != 1 {
+		t.Errorf("Unexpected summary %v", list[0])
+	}
+
+	if err := reopened.Delete(ctx, second.MapId); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := reopened.Load(ctx, second.MapId); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after Delete, got %v", err)
+	}
+}
+
+func TestFileStoreListSkipsBadFiles(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, t.TempDir())
+	good, err := s.Save(ctx, testMap("good"))
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// One file is not a map and another cannot be read at all
+	corrupt := s.path("0123456789abcdef0123456789abcdef")
+	if err := os.WriteFile(corrupt, []byte("not a map"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := os.Mkdir(s.path("fedcba9876543210fedcba9876543210"), 0o755); err != nil {
+		t.Fatalf("Mkdir failed: %v", err)
+	}
+
+	list, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 1 || list[0].MapId != good.MapId {
+		t.Errorf("Expected only map %s listed, got %v", good.MapId, list)
+	}
+}
+
+func TestFileStoreNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, t.TempDir())
+	unknown := "0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{name: "Load Unknown", run: func() error { _, err := s.Load(ctx, unknown); return err }},
+		{name: "Load Path", run: func() error { _, err := s.Load(ctx, "../secret"); return err }},
+		{name: "Delete Unknown", run: func() error { return s.Delete(ctx, unknown) }},
+		{
+			name: "Overwrite Unknown",
+			run: func() error {
+				m := testMap("ghost")
+				m.MapId = unknown
+				_, err := s.Save(ctx, m)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Expected ErrNotFound, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Package store persists saved maps for the geometry service.
+package store
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/ORBWARRIOR/PolyNav/backend/pkg/proto"
+)
+
+// ErrNotFound is returned for a map ID the repository does not hold.
+var ErrNotFound = errors.New("map not found")
+
+// Repository stores maps by ID. Implementations must be safe for
+// concurrent use.
+type Repository interface {
+	// Save stores m and returns the stored copy. An empty MapId saves a new
+	// map under a fresh ID; otherwise the existing map is replaced, keeping
+	// its creation time. The timestamps are set by the repository.
+	Save(ctx context.Context, m *pb.StoredMap) (*pb.StoredMap, error)
+	Load(ctx context.Context, id string) (*pb.StoredMap, error)
+	// List summarises every map, most recently updated first.
+	List(ctx context.Context) ([]*pb.MapSummary, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// Summarise returns the listing entry for m.
+func Summarise(m *pb.StoredMap) *pb.MapSummary {
+	return &pb.MapSummary{
+		MapId:         m.MapId,
+		Name:          m.Name,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		ObstacleCount: int32(len(m.GetMap().GetObstacles())),
+	}
+}
@@ -21,6 +21,174 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// What a triangle of the mesh covers
+type Region int32
+
+const (
+	// Never set by the server; a triangle left at the default is unclassified
+	Region_REGION_UNSPECIFIED Region = 0
+	Region_OBSTACLE           Region = 1
+	Region_FREE               Region = 2
+)
+
+// Enum value maps for Region.
+var (
+	Region_name = map[int32]string{
+		0: "REGION_UNSPECIFIED",
+		1: "OBSTACLE",
+		2: "FREE",
+	}
+	Region_value = map[string]int32{
+		"REGION_UNSPECIFIED": 0,
+		"OBSTACLE":           1,
+		"FREE":               2,
+	}
+)
+
+func (x Region) Enum() *Region {
+	p := new(Region)
+	*p = x
+	return p
+}
+
+func (x Region) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Region) Descriptor() protoreflect.EnumDescriptor {
+	return file_polynav_proto_enumTypes[0].Descriptor()
+}
+
+func (Region) Type() protoreflect.EnumType {
+	return &file_polynav_proto_enumTypes[0]
+}
+
+func (x Region) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Region.Descriptor instead.
+func (Region) EnumDescriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{0}
+}
+
+// Outcome of a path search
+type PathStatus int32
+
+const (
+	// Never set by the server; a result left at the default is not a path
+	PathStatus_PATH_STATUS_UNSPECIFIED PathStatus = 0
+	PathStatus_FOUND                   PathStatus = 1
+	PathStatus_NO_PATH                 PathStatus = 2
+	PathStatus_START_BLOCKED           PathStatus = 3
+	PathStatus_GOAL_BLOCKED            PathStatus = 4
+)
+
+// Enum value maps for PathStatus.
+var (
+	PathStatus_name = map[int32]string{
+		0: "PATH_STATUS_UNSPECIFIED",
+		1: "FOUND",
+		2: "NO_PATH",
+		3: "START_BLOCKED",
+		4: "GOAL_BLOCKED",
+	}
+	PathStatus_value = map[string]int32{
+		"PATH_STATUS_UNSPECIFIED": 0,
+		"FOUND":                   1,
+		"NO_PATH":                 2,
+		"START_BLOCKED":           3,
+		"GOAL_BLOCKED":            4,
+	}
+)
+
+func (x PathStatus) Enum() *PathStatus {
+	p := new(PathStatus)
+	*p = x
+	return p
+}
+
+func (x PathStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PathStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_polynav_proto_enumTypes[1].Descriptor()
+}
+
+func (PathStatus) Type() protoreflect.EnumType {
+	return &file_polynav_proto_enumTypes[1]
+}
+
+func (x PathStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PathStatus.Descriptor instead.
+func (PathStatus) EnumDescriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{1}
+}
+
+// Step of mesh construction a ProgressEvent reports on
+type Stage int32
+
+const (
+	// Never set by the server; an event left at the default has no stage
+	Stage_STAGE_UNSPECIFIED Stage = 0
+	Stage_INSERTING         Stage = 1
+	Stage_CONSTRAINING      Stage = 2
+	Stage_CLASSIFIED        Stage = 3
+	Stage_REFINED           Stage = 4
+	Stage_DONE              Stage = 5
+)
+
+// Enum value maps for Stage.
+var (
+	Stage_name = map[int32]string{
+		0: "STAGE_UNSPECIFIED",
+		1: "INSERTING",
+		2: "CONSTRAINING",
+		3: "CLASSIFIED",
+		4: "REFINED",
+		5: "DONE",
+	}
+	Stage_value = map[string]int32{
+		"STAGE_UNSPECIFIED": 0,
+		"INSERTING":         1,
+		"CONSTRAINING":      2,
+		"CLASSIFIED":        3,
+		"REFINED":           4,
+		"DONE":              5,
+	}
+)
+
+func (x Stage) Enum() *Stage {
+	p := new(Stage)
+	*p = x
+	return p
+}
+
+func (x Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_polynav_proto_enumTypes[2].Descriptor()
+}
+
+func (Stage) Type() protoreflect.EnumType {
+	return &file_polynav_proto_enumTypes[2]
+}
+
+func (x Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Stage.Descriptor instead.
+func (Stage) EnumDescriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{2}
+}
+
 // Basic geometric point
 type Point struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -75,9 +243,14 @@ func (x *Point) GetY() float64 {
 }
 
 // An obstacle defined by a series of points (polygon)
+// Nested rings alternate between solid and hole (even-odd rule)
 type Obstacle struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Points        []*Point               `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Points []*Point               `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	// Carve this ring out even where the even-odd rule would keep it. Only
+	// the area directly inside it is carved: other rings at the same depth,
+	// and rings nested inside it, follow their own flags
+	Hole          bool `protobuf:"varint,2,opt,name=hole,proto3" json:"hole,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -119,12 +292,33 @@ func (x *Obstacle) GetPoints() []*Point {
 	return nil
 }
 
+func (x *Obstacle) GetHole() bool {
+	if x != nil {
+		return x.Hole
+	}
+	return false
+}
+
 // Map data containing all obstacles and start/goal points
 type MapData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Obstacles     []*Obstacle            `protobuf:"bytes,1,rep,name=obstacles,proto3" json:"obstacles,omitempty"`
-	Start         *Point                 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
-	Goal          *Point                 `protobuf:"bytes,3,opt,name=goal,proto3" json:"goal,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Obstacles   []*Obstacle            `protobuf:"bytes,1,rep,name=obstacles,proto3" json:"obstacles,omitempty"`
+	Start       *Point                 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Goal        *Point                 `protobuf:"bytes,3,opt,name=goal,proto3" json:"goal,omitempty"`
+	PathOptions *PathOptions           `protobuf:"bytes,4,opt,name=path_options,json=pathOptions,proto3" json:"path_options,omitempty"`
+	// Quality refinement applied to both regions once they are classified;
+	// unset skips it
+	Refine *RefineOptions `protobuf:"bytes,5,opt,name=refine,proto3" json:"refine,omitempty"`
+	// Outer limit of the navigable area; obstacles outside it are ignored.
+	// Paths run through the free space around the obstacles, which without
+	// a boundary ends at the convex hull of the map's points
+	Boundary *Obstacle `protobuf:"bytes,6,opt,name=boundary,proto3" json:"boundary,omitempty"`
+	// Saved map to overwrite; empty saves a new map
+	MapId string `protobuf:"bytes,7,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	Name  string `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	// Leave TriangulationResult.triangles empty, for clients that only read
+	// the mesh and want the smaller response
+	OmitTriangles bool `protobuf:"varint,10,opt,name=omit_triangles,json=omitTriangles,proto3" json:"omit_triangles,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -180,20 +374,186 @@ func (x *MapData) GetGoal() *Point {
 	return nil
 }
 
+func (x *MapData) GetPathOptions() *PathOptions {
+	if x != nil {
+		return x.PathOptions
+	}
+	return nil
+}
+
+func (x *MapData) GetRefine() *RefineOptions {
+	if x != nil {
+		return x.Refine
+	}
+	return nil
+}
+
+func (x *MapData) GetBoundary() *Obstacle {
+	if x != nil {
+		return x.Boundary
+	}
+	return nil
+}
+
+func (x *MapData) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+func (x *MapData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MapData) GetOmitTriangles() bool {
+	if x != nil {
+		return x.OmitTriangles
+	}
+	return false
+}
+
+// Bounds for Ruppert refinement; zero disables a bound
+type RefineOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Smallest allowed triangle angle in degrees (at most 34)
+	MinAngle float64 `protobuf:"fixed64,1,opt,name=min_angle,json=minAngle,proto3" json:"min_angle,omitempty"`
+	MaxArea  float64 `protobuf:"fixed64,2,opt,name=max_area,json=maxArea,proto3" json:"max_area,omitempty"`
+	// Cap on inserted points; 0 uses the default of 10 per input vertex
+	MaxSteiner    int32 `protobuf:"varint,3,opt,name=max_steiner,json=maxSteiner,proto3" json:"max_steiner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefineOptions) Reset() {
+	*x = RefineOptions{}
+	mi := &file_polynav_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefineOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefineOptions) ProtoMessage() {}
+
+func (x *RefineOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefineOptions.ProtoReflect.Descriptor instead.
+func (*RefineOptions) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RefineOptions) GetMinAngle() float64 {
+	if x != nil {
+		return x.MinAngle
+	}
+	return 0
+}
+
+func (x *RefineOptions) GetMaxArea() float64 {
+	if x != nil {
+		return x.MaxArea
+	}
+	return 0
+}
+
+func (x *RefineOptions) GetMaxSteiner() int32 {
+	if x != nil {
+		return x.MaxSteiner
+	}
+	return 0
+}
+
+// Tuning for FindPath
+type PathOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pull the path taut through the triangle corridor (funnel algorithm)
+	Smooth bool `protobuf:"varint,1,opt,name=smooth,proto3" json:"smooth,omitempty"`
+	// Radius of the robot; portals narrower than its diameter are pruned
+	AgentRadius   float64 `protobuf:"fixed64,2,opt,name=agent_radius,json=agentRadius,proto3" json:"agent_radius,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathOptions) Reset() {
+	*x = PathOptions{}
+	mi := &file_polynav_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathOptions) ProtoMessage() {}
+
+func (x *PathOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathOptions.ProtoReflect.Descriptor instead.
+func (*PathOptions) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PathOptions) GetSmooth() bool {
+	if x != nil {
+		return x.Smooth
+	}
+	return false
+}
+
+func (x *PathOptions) GetAgentRadius() float64 {
+	if x != nil {
+		return x.AgentRadius
+	}
+	return 0
+}
+
 // Result of a triangulation request
 type Triangle struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	A                *Point                 `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
-	B                *Point                 `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
-	C                *Point                 `protobuf:"bytes,3,opt,name=c,proto3" json:"c,omitempty"`
-	ConstrainedEdges []bool                 `protobuf:"varint,4,rep,packed,name=constrained_edges,json=constrainedEdges,proto3" json:"constrained_edges,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	A     *Point                 `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B     *Point                 `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	C     *Point                 `protobuf:"bytes,3,opt,name=c,proto3" json:"c,omitempty"`
+	// Edge constraints: [0] = BC, [1] = CA, [2] = AB
+	ConstrainedEdges []bool `protobuf:"varint,4,rep,packed,name=constrained_edges,json=constrainedEdges,proto3" json:"constrained_edges,omitempty"`
+	// Triangulate returns the whole hull, so both regions appear: the one
+	// FindPath plans through and the one it carves away
+	Region Region `protobuf:"varint,5,opt,name=region,proto3,enum=polynav.Region" json:"region,omitempty"`
+	// Constrained edges crossed to reach the triangle from outside the map
+	Depth         int32 `protobuf:"varint,6,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Triangle) Reset() {
 	*x = Triangle{}
-	mi := &file_polynav_proto_msgTypes[3]
+	mi := &file_polynav_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -205,7 +565,7 @@ func (x *Triangle) String() string {
 func (*Triangle) ProtoMessage() {}
 
 func (x *Triangle) ProtoReflect() protoreflect.Message {
-	mi := &file_polynav_proto_msgTypes[3]
+	mi := &file_polynav_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,7 +578,7 @@ func (x *Triangle) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Triangle.ProtoReflect.Descriptor instead.
 func (*Triangle) Descriptor() ([]byte, []int) {
-	return file_polynav_proto_rawDescGZIP(), []int{3}
+	return file_polynav_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Triangle) GetA() *Point {
@@ -249,16 +609,33 @@ func (x *Triangle) GetConstrainedEdges() []bool {
 	return nil
 }
 
+func (x *Triangle) GetRegion() Region {
+	if x != nil {
+		return x.Region
+	}
+	return Region_REGION_UNSPECIFIED
+}
+
+func (x *Triangle) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
 type TriangulationResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Triangles     []*Triangle            `protobuf:"bytes,1,rep,name=triangles,proto3" json:"triangles,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty when MapData.omit_triangles is set
+	Triangles []*Triangle `protobuf:"bytes,1,rep,name=triangles,proto3" json:"triangles,omitempty"`
+	// The same triangles, in the same order, with shared vertices
+	Mesh          *IndexedMesh `protobuf:"bytes,2,opt,name=mesh,proto3" json:"mesh,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TriangulationResult) Reset() {
 	*x = TriangulationResult{}
-	mi := &file_polynav_proto_msgTypes[4]
+	mi := &file_polynav_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -270,7 +647,7 @@ func (x *TriangulationResult) String() string {
 func (*TriangulationResult) ProtoMessage() {}
 
 func (x *TriangulationResult) ProtoReflect() protoreflect.Message {
-	mi := &file_polynav_proto_msgTypes[4]
+	mi := &file_polynav_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -283,7 +660,7 @@ func (x *TriangulationResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriangulationResult.ProtoReflect.Descriptor instead.
 func (*TriangulationResult) Descriptor() ([]byte, []int) {
-	return file_polynav_proto_rawDescGZIP(), []int{4}
+	return file_polynav_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TriangulationResult) GetTriangles() []*Triangle {
@@ -293,30 +670,53 @@ func (x *TriangulationResult) GetTriangles() []*Triangle {
 	return nil
 }
 
-type SaveMapResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	MapId         string                 `protobuf:"bytes,3,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+func (x *TriangulationResult) GetMesh() *IndexedMesh {
+	if x != nil {
+		return x.Mesh
+	}
+	return nil
+}
+
+// A mesh as flat buffers. Triangle i owns entries 3i to 3i+2 of triangles,
+// neighbors and constrained_edges; slot k holds its k-th vertex and the
+// neighbour and edge opposite it.
+type IndexedMesh struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Vertices []*Point               `protobuf:"bytes,1,rep,name=vertices,proto3" json:"vertices,omitempty"`
+	// Vertex indices, counter-clockwise
+	Triangles []int32 `protobuf:"varint,2,rep,packed,name=triangles,proto3" json:"triangles,omitempty"`
+	// Triangle indices, -1 on the boundary
+	Neighbors        []int32 `protobuf:"varint,3,rep,packed,name=neighbors,proto3" json:"neighbors,omitempty"`
+	ConstrainedEdges []bool  `protobuf:"varint,4,rep,packed,name=constrained_edges,json=constrainedEdges,proto3" json:"constrained_edges,omitempty"`
+	// Per vertex: position among the request's points (obstacles in order,
+	// then boundary, start and goal), -1 for vertices the server inserted.
+	// Points of obstacles outside the boundary are counted but have no
+	// vertex. A vertex merged from duplicate points reports the first.
+	// GetMesh counts the session's current map, its obstacles in ID order
+	InputIndices []int32 `protobuf:"varint,5,rep,packed,name=input_indices,json=inputIndices,proto3" json:"input_indices,omitempty"`
+	// Per triangle, as Triangle.region and Triangle.depth; empty in
+	// snapshots taken before the mesh is classified
+	Regions       []Region `protobuf:"varint,6,rep,packed,name=regions,proto3,enum=polynav.Region" json:"regions,omitempty"`
+	Depths        []int32  `protobuf:"varint,7,rep,packed,name=depths,proto3" json:"depths,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SaveMapResponse) Reset() {
-	*x = SaveMapResponse{}
-	mi := &file_polynav_proto_msgTypes[5]
+func (x *IndexedMesh) Reset() {
+	*x = IndexedMesh{}
+	mi := &file_polynav_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveMapResponse) String() string {
+func (x *IndexedMesh) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveMapResponse) ProtoMessage() {}
+func (*IndexedMesh) ProtoMessage() {}
 
-func (x *SaveMapResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_polynav_proto_msgTypes[5]
+func (x *IndexedMesh) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,60 +727,1221 @@ func (x *SaveMapResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveMapResponse.ProtoReflect.Descriptor instead.
-func (*SaveMapResponse) Descriptor() ([]byte, []int) {
-	return file_polynav_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use IndexedMesh.ProtoReflect.Descriptor instead.
+func (*IndexedMesh) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SaveMapResponse) GetSuccess() bool {
+func (x *IndexedMesh) GetVertices() []*Point {
 	if x != nil {
-		return x.Success
+		return x.Vertices
 	}
-	return false
+	return nil
 }
 
-func (x *SaveMapResponse) GetMessage() string {
+func (x *IndexedMesh) GetTriangles() []int32 {
 	if x != nil {
-		return x.Message
+		return x.Triangles
 	}
-	return ""
+	return nil
 }
 
-func (x *SaveMapResponse) GetMapId() string {
+func (x *IndexedMesh) GetNeighbors() []int32 {
 	if x != nil {
-		return x.MapId
+		return x.Neighbors
 	}
-	return ""
+	return nil
 }
 
-var File_polynav_proto protoreflect.FileDescriptor
+func (x *IndexedMesh) GetConstrainedEdges() []bool {
+	if x != nil {
+		return x.ConstrainedEdges
+	}
+	return nil
+}
 
-const file_polynav_proto_rawDesc = "" +
-	"\n" +
-	"\rpolynav.proto\x12\apolynav\"#\n" +
-	"\x05Point\x12\f\n" +
-	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
-	"\x01y\x18\x02 \x01(\x01R\x01y\"2\n" +
-	"\bObstacle\x12&\n" +
-	"\x06points\x18\x01 \x03(\v2\x0e.polynav.PointR\x06points\"\x84\x01\n" +
-	"\aMapData\x12/\n" +
-	"\tobstacles\x18\x01 \x03(\v2\x11.polynav.ObstacleR\tobstacles\x12$\n" +
-	"\x05start\x18\x02 \x01(\v2\x0e.polynav.PointR\x05start\x12\"\n" +
-	"\x04goal\x18\x03 \x01(\v2\x0e.polynav.PointR\x04goal\"d\n" +
-	"\bTriangle\x12\x1c\n" +
-	"\x01a\x18\x01 \x01(\v2\x0e.polynav.PointR\x01a\x12\x1c\n" +
-	"\x01b\x18\x02 \x01(\v2\x0e.polynav.PointR\x01b\x12\x1c\n" +
-	"\x01c\x18\x03 \x01(\v2\x0e.polynav.PointR\x01c\"F\n" +
-	"\x13TriangulationResult\x12/\n" +
-	"\ttriangles\x18\x01 \x03(\v2\x11.polynav.TriangleR\ttriangles\"\\\n" +
+func (x *IndexedMesh) GetInputIndices() []int32 {
+	if x != nil {
+		return x.InputIndices
+	}
+	return nil
+}
+
+func (x *IndexedMesh) GetRegions() []Region {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *IndexedMesh) GetDepths() []int32 {
+	if x != nil {
+		return x.Depths
+	}
+	return nil
+}
+
+// Result of a path planning request
+type PathResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Status    PathStatus             `protobuf:"varint,1,opt,name=status,proto3,enum=polynav.PathStatus" json:"status,omitempty"`
+	Waypoints []*Point               `protobuf:"bytes,2,rep,name=waypoints,proto3" json:"waypoints,omitempty"`
+	Length    float64                `protobuf:"fixed64,3,opt,name=length,proto3" json:"length,omitempty"`
+	// Indices into TriangulationResult.triangles (and the mesh's triangles)
+	// for the same MapData, in traversal order
+	Triangles     []int32 `protobuf:"varint,4,rep,packed,name=triangles,proto3" json:"triangles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathResult) Reset() {
+	*x = PathResult{}
+	mi := &file_polynav_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathResult) ProtoMessage() {}
+
+func (x *PathResult) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathResult.ProtoReflect.Descriptor instead.
+func (*PathResult) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PathResult) GetStatus() PathStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PathStatus_PATH_STATUS_UNSPECIFIED
+}
+
+func (x *PathResult) GetWaypoints() []*Point {
+	if x != nil {
+		return x.Waypoints
+	}
+	return nil
+}
+
+func (x *PathResult) GetLength() float64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *PathResult) GetTriangles() []int32 {
+	if x != nil {
+		return x.Triangles
+	}
+	return nil
+}
+
+type StreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Map   *MapData               `protobuf:"bytes,1,opt,name=map,proto3" json:"map,omitempty"`
+	// Attach a mesh snapshot every this many point insertions; 0 sends none
+	SnapshotEvery int32 `protobuf:"varint,2,opt,name=snapshot_every,json=snapshotEvery,proto3" json:"snapshot_every,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRequest) Reset() {
+	*x = StreamRequest{}
+	mi := &file_polynav_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRequest) ProtoMessage() {}
+
+func (x *StreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRequest.ProtoReflect.Descriptor instead.
+func (*StreamRequest) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StreamRequest) GetMap() *MapData {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+func (x *StreamRequest) GetSnapshotEvery() int32 {
+	if x != nil {
+		return x.SnapshotEvery
+	}
+	return 0
+}
+
+type ProgressEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Stage Stage                  `protobuf:"varint,1,opt,name=stage,proto3,enum=polynav.Stage" json:"stage,omitempty"`
+	// Points inserted or constraints applied so far, triangles kept once
+	// classified, Steiner points once refined
+	Done  int32 `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	Total int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// Mesh of the points inserted so far, when a snapshot is due
+	Snapshot *IndexedMesh `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Set on the DONE event only
+	Result        *TriangulationResult `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressEvent) Reset() {
+	*x = ProgressEvent{}
+	mi := &file_polynav_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressEvent) ProtoMessage() {}
+
+func (x *ProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressEvent.ProtoReflect.Descriptor instead.
+func (*ProgressEvent) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProgressEvent) GetStage() Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return Stage_STAGE_UNSPECIFIED
+}
+
+func (x *ProgressEvent) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *ProgressEvent) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ProgressEvent) GetSnapshot() *IndexedMesh {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *ProgressEvent) GetResult() *TriangulationResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type SaveMapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MapId         string                 `protobuf:"bytes,3,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveMapResponse) Reset() {
+	*x = SaveMapResponse{}
+	mi := &file_polynav_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveMapResponse) ProtoMessage() {}
+
+func (x *SaveMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveMapResponse.ProtoReflect.Descriptor instead.
+func (*SaveMapResponse) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SaveMapResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SaveMapResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SaveMapResponse) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+// A map as the map store keeps it
+type StoredMap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	MapId string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Map   *MapData               `protobuf:"bytes,3,opt,name=map,proto3" json:"map,omitempty"`
+	// Unix time in milliseconds
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Triangulation when the map was saved; unset if it could not be built
+	Mesh          *TriangulationResult `protobuf:"bytes,6,opt,name=mesh,proto3" json:"mesh,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoredMap) Reset() {
+	*x = StoredMap{}
+	mi := &file_polynav_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoredMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoredMap) ProtoMessage() {}
+
+func (x *StoredMap) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoredMap.ProtoReflect.Descriptor instead.
+func (*StoredMap) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StoredMap) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+func (x *StoredMap) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StoredMap) GetMap() *MapData {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+func (x *StoredMap) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *StoredMap) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *StoredMap) GetMesh() *TriangulationResult {
+	if x != nil {
+		return x.Mesh
+	}
+	return nil
+}
+
+type MapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MapId         string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapRequest) Reset() {
+	*x = MapRequest{}
+	mi := &file_polynav_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapRequest) ProtoMessage() {}
+
+func (x *MapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapRequest.ProtoReflect.Descriptor instead.
+func (*MapRequest) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MapRequest) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+type ListMapsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMapsRequest) Reset() {
+	*x = ListMapsRequest{}
+	mi := &file_polynav_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMapsRequest) ProtoMessage() {}
+
+func (x *ListMapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMapsRequest.ProtoReflect.Descriptor instead.
+func (*ListMapsRequest) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{14}
+}
+
+type MapSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MapId         string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ObstacleCount int32                  `protobuf:"varint,5,opt,name=obstacle_count,json=obstacleCount,proto3" json:"obstacle_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapSummary) Reset() {
+	*x = MapSummary{}
+	mi := &file_polynav_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapSummary) ProtoMessage() {}
+
+func (x *MapSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapSummary.ProtoReflect.Descriptor instead.
+func (*MapSummary) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MapSummary) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+func (x *MapSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MapSummary) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *MapSummary) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *MapSummary) GetObstacleCount() int32 {
+	if x != nil {
+		return x.ObstacleCount
+	}
+	return 0
+}
+
+type MapList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Maps          []*MapSummary          `protobuf:"bytes,1,rep,name=maps,proto3" json:"maps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapList) Reset() {
+	*x = MapList{}
+	mi := &file_polynav_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapList) ProtoMessage() {}
+
+func (x *MapList) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapList.ProtoReflect.Descriptor instead.
+func (*MapList) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MapList) GetMaps() []*MapSummary {
+	if x != nil {
+		return x.Maps
+	}
+	return nil
+}
+
+type DeleteMapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MapId         string                 `protobuf:"bytes,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMapResponse) Reset() {
+	*x = DeleteMapResponse{}
+	mi := &file_polynav_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMapResponse) ProtoMessage() {}
+
+func (x *DeleteMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMapResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMapResponse) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteMapResponse) GetMapId() string {
+	if x != nil {
+		return x.MapId
+	}
+	return ""
+}
+
+type SessionInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// IDs given to the map's obstacles, in order; -1 for obstacles outside
+	// the boundary, which are ignored
+	ObstacleIds   []int32 `protobuf:"varint,2,rep,packed,name=obstacle_ids,json=obstacleIds,proto3" json:"obstacle_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	mi := &file_polynav_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SessionInfo) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetObstacleIds() []int32 {
+	if x != nil {
+		return x.ObstacleIds
+	}
+	return nil
+}
+
+type SessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_polynav_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type AddObstacleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Obstacle      *Obstacle              `protobuf:"bytes,2,opt,name=obstacle,proto3" json:"obstacle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddObstacleRequest) Reset() {
+	*x = AddObstacleRequest{}
+	mi := &file_polynav_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddObstacleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddObstacleRequest) ProtoMessage() {}
+
+func (x *AddObstacleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddObstacleRequest.ProtoReflect.Descriptor instead.
+func (*AddObstacleRequest) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AddObstacleRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AddObstacleRequest) GetObstacle() *Obstacle {
+	if x != nil {
+		return x.Obstacle
+	}
+	return nil
+}
+
+type ObstacleRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ObstacleId    int32                  `protobuf:"varint,2,opt,name=obstacle_id,json=obstacleId,proto3" json:"obstacle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObstacleRef) Reset() {
+	*x = ObstacleRef{}
+	mi := &file_polynav_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObstacleRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObstacleRef) ProtoMessage() {}
+
+func (x *ObstacleRef) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObstacleRef.ProtoReflect.Descriptor instead.
+func (*ObstacleRef) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ObstacleRef) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ObstacleRef) GetObstacleId() int32 {
+	if x != nil {
+		return x.ObstacleId
+	}
+	return 0
+}
+
+type MovePointRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SessionId  string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ObstacleId int32                  `protobuf:"varint,2,opt,name=obstacle_id,json=obstacleId,proto3" json:"obstacle_id,omitempty"`
+	// Position of the point in the obstacle's ring
+	PointIndex    int32  `protobuf:"varint,3,opt,name=point_index,json=pointIndex,proto3" json:"point_index,omitempty"`
+	To            *Point `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovePointRequest) Reset() {
+	*x = MovePointRequest{}
+	mi := &file_polynav_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovePointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovePointRequest) ProtoMessage() {}
+
+func (x *MovePointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovePointRequest.ProtoReflect.Descriptor instead.
+func (*MovePointRequest) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MovePointRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *MovePointRequest) GetObstacleId() int32 {
+	if x != nil {
+		return x.ObstacleId
+	}
+	return 0
+}
+
+func (x *MovePointRequest) GetPointIndex() int32 {
+	if x != nil {
+		return x.PointIndex
+	}
+	return 0
+}
+
+func (x *MovePointRequest) GetTo() *Point {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// A broken mesh invariant
+type Violation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orientation, neighbour, constraint, delaunay or euler
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Offending triangle and its neighbour, -1 when not applicable
+	Triangle      int32  `protobuf:"varint,2,opt,name=triangle,proto3" json:"triangle,omitempty"`
+	Neighbor      int32  `protobuf:"varint,3,opt,name=neighbor,proto3" json:"neighbor,omitempty"`
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Violation) Reset() {
+	*x = Violation{}
+	mi := &file_polynav_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Violation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Violation) ProtoMessage() {}
+
+func (x *Violation) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Violation.ProtoReflect.Descriptor instead.
+func (*Violation) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Violation) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Violation) GetTriangle() int32 {
+	if x != nil {
+		return x.Triangle
+	}
+	return 0
+}
+
+func (x *Violation) GetNeighbor() int32 {
+	if x != nil {
+		return x.Neighbor
+	}
+	return 0
+}
+
+func (x *Violation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ValidationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Violations    []*Violation           `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	TriangleCount int32                  `protobuf:"varint,3,opt,name=triangle_count,json=triangleCount,proto3" json:"triangle_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResult) Reset() {
+	*x = ValidationResult{}
+	mi := &file_polynav_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult) ProtoMessage() {}
+
+func (x *ValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_polynav_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult.ProtoReflect.Descriptor instead.
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return file_polynav_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ValidationResult) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidationResult) GetViolations() []*Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetTriangleCount() int32 {
+	if x != nil {
+		return x.TriangleCount
+	}
+	return 0
+}
+
+var File_polynav_proto protoreflect.FileDescriptor
+
+const file_polynav_proto_rawDesc = "" +
+	"\n" +
+	"\rpolynav.proto\x12\apolynav\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\"F\n" +
+	"\bObstacle\x12&\n" +
+	"\x06points\x18\x01 \x03(\v2\x0e.polynav.PointR\x06points\x12\x12\n" +
+	"\x04hole\x18\x02 \x01(\bR\x04hole\"\x86\x03\n" +
+	"\aMapData\x12/\n" +
+	"\tobstacles\x18\x01 \x03(\v2\x11.polynav.ObstacleR\tobstacles\x12$\n" +
+	"\x05start\x18\x02 \x01(\v2\x0e.polynav.PointR\x05start\x12\"\n" +
+	"\x04goal\x18\x03 \x01(\v2\x0e.polynav.PointR\x04goal\x127\n" +
+	"\fpath_options\x18\x04 \x01(\v2\x14.polynav.PathOptionsR\vpathOptions\x12.\n" +
+	"\x06refine\x18\x05 \x01(\v2\x16.polynav.RefineOptionsR\x06refine\x12-\n" +
+	"\bboundary\x18\x06 \x01(\v2\x11.polynav.ObstacleR\bboundary\x12\x15\n" +
+	"\x06map_id\x18\a \x01(\tR\x05mapId\x12\x12\n" +
+	"\x04name\x18\b \x01(\tR\x04name\x12%\n" +
+	"\x0eomit_triangles\x18\n" +
+	" \x01(\bR\romitTrianglesJ\x04\b\t\x10\n" +
+	"R\x10legacy_triangles\"h\n" +
+	"\rRefineOptions\x12\x1b\n" +
+	"\tmin_angle\x18\x01 \x01(\x01R\bminAngle\x12\x19\n" +
+	"\bmax_area\x18\x02 \x01(\x01R\amaxArea\x12\x1f\n" +
+	"\vmax_steiner\x18\x03 \x01(\x05R\n" +
+	"maxSteiner\"H\n" +
+	"\vPathOptions\x12\x16\n" +
+	"\x06smooth\x18\x01 \x01(\bR\x06smooth\x12!\n" +
+	"\fagent_radius\x18\x02 \x01(\x01R\vagentRadius\"\xd0\x01\n" +
+	"\bTriangle\x12\x1c\n" +
+	"\x01a\x18\x01 \x01(\v2\x0e.polynav.PointR\x01a\x12\x1c\n" +
+	"\x01b\x18\x02 \x01(\v2\x0e.polynav.PointR\x01b\x12\x1c\n" +
+	"\x01c\x18\x03 \x01(\v2\x0e.polynav.PointR\x01c\x12+\n" +
+	"\x11constrained_edges\x18\x04 \x03(\bR\x10constrainedEdges\x12'\n" +
+	"\x06region\x18\x05 \x01(\x0e2\x0f.polynav.RegionR\x06region\x12\x14\n" +
+	"\x05depth\x18\x06 \x01(\x05R\x05depth\"p\n" +
+	"\x13TriangulationResult\x12/\n" +
+	"\ttriangles\x18\x01 \x03(\v2\x11.polynav.TriangleR\ttriangles\x12(\n" +
+	"\x04mesh\x18\x02 \x01(\v2\x14.polynav.IndexedMeshR\x04mesh\"\x8a\x02\n" +
+	"\vIndexedMesh\x12*\n" +
+	"\bvertices\x18\x01 \x03(\v2\x0e.polynav.PointR\bvertices\x12\x1c\n" +
+	"\ttriangles\x18\x02 \x03(\x05R\ttriangles\x12\x1c\n" +
+	"\tneighbors\x18\x03 \x03(\x05R\tneighbors\x12+\n" +
+	"\x11constrained_edges\x18\x04 \x03(\bR\x10constrainedEdges\x12#\n" +
+	"\rinput_indices\x18\x05 \x03(\x05R\finputIndices\x12)\n" +
+	"\aregions\x18\x06 \x03(\x0e2\x0f.polynav.RegionR\aregions\x12\x16\n" +
+	"\x06depths\x18\a \x03(\x05R\x06depths\"\x9d\x01\n" +
+	"\n" +
+	"PathResult\x12+\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x13.polynav.PathStatusR\x06status\x12,\n" +
+	"\twaypoints\x18\x02 \x03(\v2\x0e.polynav.PointR\twaypoints\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x01R\x06length\x12\x1c\n" +
+	"\ttriangles\x18\x04 \x03(\x05R\ttriangles\"Z\n" +
+	"\rStreamRequest\x12\"\n" +
+	"\x03map\x18\x01 \x01(\v2\x10.polynav.MapDataR\x03map\x12%\n" +
+	"\x0esnapshot_every\x18\x02 \x01(\x05R\rsnapshotEvery\"\xc7\x01\n" +
+	"\rProgressEvent\x12$\n" +
+	"\x05stage\x18\x01 \x01(\x0e2\x0e.polynav.StageR\x05stage\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\x05R\x04done\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x120\n" +
+	"\bsnapshot\x18\x04 \x01(\v2\x14.polynav.IndexedMeshR\bsnapshot\x124\n" +
+	"\x06result\x18\x05 \x01(\v2\x1c.polynav.TriangulationResultR\x06result\"\\\n" +
 	"\x0fSaveMapResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x15\n" +
-	"\x06map_id\x18\x03 \x01(\tR\x05mapId2\x87\x01\n" +
+	"\x06map_id\x18\x03 \x01(\tR\x05mapId\"\xca\x01\n" +
+	"\tStoredMap\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
+	"\x03map\x18\x03 \x01(\v2\x10.polynav.MapDataR\x03map\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x120\n" +
+	"\x04mesh\x18\x06 \x01(\v2\x1c.polynav.TriangulationResultR\x04mesh\"#\n" +
+	"\n" +
+	"MapRequest\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\"\x11\n" +
+	"\x0fListMapsRequest\"\x9c\x01\n" +
+	"\n" +
+	"MapSummary\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\x12%\n" +
+	"\x0eobstacle_count\x18\x05 \x01(\x05R\robstacleCount\"2\n" +
+	"\aMapList\x12'\n" +
+	"\x04maps\x18\x01 \x03(\v2\x13.polynav.MapSummaryR\x04maps\"*\n" +
+	"\x11DeleteMapResponse\x12\x15\n" +
+	"\x06map_id\x18\x01 \x01(\tR\x05mapId\"O\n" +
+	"\vSessionInfo\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12!\n" +
+	"\fobstacle_ids\x18\x02 \x03(\x05R\vobstacleIds\"/\n" +
+	"\x0eSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"b\n" +
+	"\x12AddObstacleRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12-\n" +
+	"\bobstacle\x18\x02 \x01(\v2\x11.polynav.ObstacleR\bobstacle\"M\n" +
+	"\vObstacleRef\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1f\n" +
+	"\vobstacle_id\x18\x02 \x01(\x05R\n" +
+	"obstacleId\"\x93\x01\n" +
+	"\x10MovePointRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1f\n" +
+	"\vobstacle_id\x18\x02 \x01(\x05R\n" +
+	"obstacleId\x12\x1f\n" +
+	"\vpoint_index\x18\x03 \x01(\x05R\n" +
+	"pointIndex\x12\x1e\n" +
+	"\x02to\x18\x04 \x01(\v2\x0e.polynav.PointR\x02to\"q\n" +
+	"\tViolation\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1a\n" +
+	"\btriangle\x18\x02 \x01(\x05R\btriangle\x12\x1a\n" +
+	"\bneighbor\x18\x03 \x01(\x05R\bneighbor\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x83\x01\n" +
+	"\x10ValidationResult\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x122\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2\x12.polynav.ViolationR\n" +
+	"violations\x12%\n" +
+	"\x0etriangle_count\x18\x03 \x01(\x05R\rtriangleCount*8\n" +
+	"\x06Region\x12\x16\n" +
+	"\x12REGION_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bOBSTACLE\x10\x01\x12\b\n" +
+	"\x04FREE\x10\x02*f\n" +
+	"\n" +
+	"PathStatus\x12\x1b\n" +
+	"\x17PATH_STATUS_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05FOUND\x10\x01\x12\v\n" +
+	"\aNO_PATH\x10\x02\x12\x11\n" +
+	"\rSTART_BLOCKED\x10\x03\x12\x10\n" +
+	"\fGOAL_BLOCKED\x10\x04*f\n" +
+	"\x05Stage\x12\x15\n" +
+	"\x11STAGE_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tINSERTING\x10\x01\x12\x10\n" +
+	"\fCONSTRAINING\x10\x02\x12\x0e\n" +
+	"\n" +
+	"CLASSIFIED\x10\x03\x12\v\n" +
+	"\aREFINED\x10\x04\x12\b\n" +
+	"\x04DONE\x10\x052\xe0\x06\n" +
 	"\x0fGeometryService\x12=\n" +
-	"\vTriangulate\x12\x10.polynav.MapData\x1a\x1c.polynav.TriangulationResult\x125\n" +
-	"\aSaveMap\x12\x10.polynav.MapData\x1a\x18.polynav.SaveMapResponseB.\n" +
-	"\rfyp.generatedB\fPolyNavProtoP\x01Z\rpolynav/protob\x06proto3"
+	"\vTriangulate\x12\x10.polynav.MapData\x1a\x1c.polynav.TriangulationResult\x121\n" +
+	"\bFindPath\x12\x10.polynav.MapData\x1a\x13.polynav.PathResult\x125\n" +
+	"\aSaveMap\x12\x10.polynav.MapData\x1a\x18.polynav.SaveMapResponse\x122\n" +
+	"\aLoadMap\x12\x13.polynav.MapRequest\x1a\x12.polynav.StoredMap\x126\n" +
+	"\bListMaps\x12\x18.polynav.ListMapsRequest\x1a\x10.polynav.MapList\x12<\n" +
+	"\tDeleteMap\x12\x13.polynav.MapRequest\x1a\x1a.polynav.DeleteMapResponse\x12;\n" +
+	"\fValidateMesh\x12\x10.polynav.MapData\x1a\x19.polynav.ValidationResult\x12E\n" +
+	"\x11TriangulateStream\x12\x16.polynav.StreamRequest\x1a\x16.polynav.ProgressEvent0\x01\x127\n" +
+	"\rCreateSession\x12\x10.polynav.MapData\x1a\x14.polynav.SessionInfo\x12@\n" +
+	"\vAddObstacle\x12\x1b.polynav.AddObstacleRequest\x1a\x14.polynav.ObstacleRef\x12<\n" +
+	"\x0eRemoveObstacle\x12\x14.polynav.ObstacleRef\x1a\x14.polynav.ObstacleRef\x12<\n" +
+	"\tMovePoint\x12\x19.polynav.MovePointRequest\x1a\x14.polynav.ObstacleRef\x12@\n" +
+	"\aGetMesh\x12\x17.polynav.SessionRequest\x1a\x1c.polynav.TriangulationResult\x12=\n" +
+	"\fCloseSession\x12\x17.polynav.SessionRequest\x1a\x14.polynav.SessionInfoBP\n" +
+	"\rfyp.generatedB\fPolyNavProtoP\x01Z/github.com/ORBWARRIOR/PolyNav/backend/pkg/protob\x06proto3"
 
 var (
 	file_polynav_proto_rawDescOnce sync.Once
@@ -394,33 +1955,99 @@ func file_polynav_proto_rawDescGZIP() []byte {
 	return file_polynav_proto_rawDescData
 }
 
-var file_polynav_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_polynav_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_polynav_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_polynav_proto_goTypes = []any{
-	(*Point)(nil),               // 0: polynav.Point
-	(*Obstacle)(nil),            // 1: polynav.Obstacle
-	(*MapData)(nil),             // 2: polynav.MapData
-	(*Triangle)(nil),            // 3: polynav.Triangle
-	(*TriangulationResult)(nil), // 4: polynav.TriangulationResult
-	(*SaveMapResponse)(nil),     // 5: polynav.SaveMapResponse
+	(Region)(0),                 // 0: polynav.Region
+	(PathStatus)(0),             // 1: polynav.PathStatus
+	(Stage)(0),                  // 2: polynav.Stage
+	(*Point)(nil),               // 3: polynav.Point
+	(*Obstacle)(nil),            // 4: polynav.Obstacle
+	(*MapData)(nil),             // 5: polynav.MapData
+	(*RefineOptions)(nil),       // 6: polynav.RefineOptions
+	(*PathOptions)(nil),         // 7: polynav.PathOptions
+	(*Triangle)(nil),            // 8: polynav.Triangle
+	(*TriangulationResult)(nil), // 9: polynav.TriangulationResult
+	(*IndexedMesh)(nil),         // 10: polynav.IndexedMesh
+	(*PathResult)(nil),          // 11: polynav.PathResult
+	(*StreamRequest)(nil),       // 12: polynav.StreamRequest
+	(*ProgressEvent)(nil),       // 13: polynav.ProgressEvent
+	(*SaveMapResponse)(nil),     // 14: polynav.SaveMapResponse
+	(*StoredMap)(nil),           // 15: polynav.StoredMap
+	(*MapRequest)(nil),          // 16: polynav.MapRequest
+	(*ListMapsRequest)(nil),     // 17: polynav.ListMapsRequest
+	(*MapSummary)(nil),          // 18: polynav.MapSummary
+	(*MapList)(nil),             // 19: polynav.MapList
+	(*DeleteMapResponse)(nil),   // 20: polynav.DeleteMapResponse
+	(*SessionInfo)(nil),         // 21: polynav.SessionInfo
+	(*SessionRequest)(nil),      // 22: polynav.SessionRequest
+	(*AddObstacleRequest)(nil),  // 23: polynav.AddObstacleRequest
+	(*ObstacleRef)(nil),         // 24: polynav.ObstacleRef
+	(*MovePointRequest)(nil),    // 25: polynav.MovePointRequest
+	(*Violation)(nil),           // 26: polynav.Violation
+	(*ValidationResult)(nil),    // 27: polynav.ValidationResult
 }
 var file_polynav_proto_depIdxs = []int32{
-	0,  // 0: polynav.Obstacle.points:type_name -> polynav.Point
-	1,  // 1: polynav.MapData.obstacles:type_name -> polynav.Obstacle
-	0,  // 2: polynav.MapData.start:type_name -> polynav.Point
-	0,  // 3: polynav.MapData.goal:type_name -> polynav.Point
-	0,  // 4: polynav.Triangle.a:type_name -> polynav.Point
-	0,  // 5: polynav.Triangle.b:type_name -> polynav.Point
-	0,  // 6: polynav.Triangle.c:type_name -> polynav.Point
-	3,  // 7: polynav.TriangulationResult.triangles:type_name -> polynav.Triangle
-	2,  // 8: polynav.GeometryService.Triangulate:input_type -> polynav.MapData
-	2,  // 9: polynav.GeometryService.SaveMap:input_type -> polynav.MapData
-	4,  // 10: polynav.GeometryService.Triangulate:output_type -> polynav.TriangulationResult
-	5,  // 11: polynav.GeometryService.SaveMap:output_type -> polynav.SaveMapResponse
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	3,  // 0: polynav.Obstacle.points:type_name -> polynav.Point
+	4,  // 1: polynav.MapData.obstacles:type_name -> polynav.Obstacle
+	3,  // 2: polynav.MapData.start:type_name -> polynav.Point
+	3,  // 3: polynav.MapData.goal:type_name -> polynav.Point
+	7,  // 4: polynav.MapData.path_options:type_name -> polynav.PathOptions
+	6,  // 5: polynav.MapData.refine:type_name -> polynav.RefineOptions
+	4,  // 6: polynav.MapData.boundary:type_name -> polynav.Obstacle
+	3,  // 7: polynav.Triangle.a:type_name -> polynav.Point
+	3,  // 8: polynav.Triangle.b:type_name -> polynav.Point
+	3,  // 9: polynav.Triangle.c:type_name -> polynav.Point
+	0,  // 10: polynav.Triangle.region:type_name -> polynav.Region
+	8,  // 11: polynav.TriangulationResult.triangles:type_name -> polynav.Triangle
+	10, // 12: polynav.TriangulationResult.mesh:type_name -> polynav.IndexedMesh
+	3,  // 13: polynav.IndexedMesh.vertices:type_name -> polynav.Point
+	0,  // 14: polynav.IndexedMesh.regions:type_name -> polynav.Region
+	1,  // 15: polynav.PathResult.status:type_name -> polynav.PathStatus
+	3,  // 16: polynav.PathResult.waypoints:type_name -> polynav.Point
+	5,  // 17: polynav.StreamRequest.map:type_name -> polynav.MapData
+	2,  // 18: polynav.ProgressEvent.stage:type_name -> polynav.Stage
+	10, // 19: polynav.ProgressEvent.snapshot:type_name -> polynav.IndexedMesh
+	9,  // 20: polynav.ProgressEvent.result:type_name -> polynav.TriangulationResult
+	5,  // 21: polynav.StoredMap.map:type_name -> polynav.MapData
+	9,  // 22: polynav.StoredMap.mesh:type_name -> polynav.TriangulationResult
+	18, // 23: polynav.MapList.maps:type_name -> polynav.MapSummary
+	4,  // 24: polynav.AddObstacleRequest.obstacle:type_name -> polynav.Obstacle
+	3,  // 25: polynav.MovePointRequest.to:type_name -> polynav.Point
+	26, // 26: polynav.ValidationResult.violations:type_name -> polynav.Violation
+	5,  // 27: polynav.GeometryService.Triangulate:input_type -> polynav.MapData
+	5,  // 28: polynav.GeometryService.FindPath:input_type -> polynav.MapData
+	5,  // 29: polynav.GeometryService.SaveMap:input_type -> polynav.MapData
+	16, // 30: polynav.GeometryService.LoadMap:input_type -> polynav.MapRequest
+	17, // 31: polynav.GeometryService.ListMaps:input_type -> polynav.ListMapsRequest
+	16, // 32: polynav.GeometryService.DeleteMap:input_type -> polynav.MapRequest
+	5,  // 33: polynav.GeometryService.ValidateMesh:input_type -> polynav.MapData
+	12, // 34: polynav.GeometryService.TriangulateStream:input_type -> polynav.StreamRequest
+	5,  // 35: polynav.GeometryService.CreateSession:input_type -> polynav.MapData
+	23, // 36: polynav.GeometryService.AddObstacle:input_type -> polynav.AddObstacleRequest
+	24, // 37: polynav.GeometryService.RemoveObstacle:input_type -> polynav.ObstacleRef
+	25, // 38: polynav.GeometryService.MovePoint:input_type -> polynav.MovePointRequest
+	22, // 39: polynav.GeometryService.GetMesh:input_type -> polynav.SessionRequest
+	22, // 40: polynav.GeometryService.CloseSession:input_type -> polynav.SessionRequest
+	9,  // 41: polynav.GeometryService.Triangulate:output_type -> polynav.TriangulationResult
+	11, // 42: polynav.GeometryService.FindPath:output_type -> polynav.PathResult
+	14, // 43: polynav.GeometryService.SaveMap:output_type -> polynav.SaveMapResponse
+	15, // 44: polynav.GeometryService.LoadMap:output_type -> polynav.StoredMap
+	19, // 45: polynav.GeometryService.ListMaps:output_type -> polynav.MapList
+	20, // 46: polynav.GeometryService.DeleteMap:output_type -> polynav.DeleteMapResponse
+	27, // 47: polynav.GeometryService.ValidateMesh:output_type -> polynav.ValidationResult
+	13, // 48: polynav.GeometryService.TriangulateStream:output_type -> polynav.ProgressEvent
+	21, // 49: polynav.GeometryService.CreateSession:output_type -> polynav.SessionInfo
+	24, // 50: polynav.GeometryService.AddObstacle:output_type -> polynav.ObstacleRef
+	24, // 51: polynav.GeometryService.RemoveObstacle:output_type -> polynav.ObstacleRef
+	24, // 52: polynav.GeometryService.MovePoint:output_type -> polynav.ObstacleRef
+	9,  // 53: polynav.GeometryService.GetMesh:output_type -> polynav.TriangulationResult
+	21, // 54: polynav.GeometryService.CloseSession:output_type -> polynav.SessionInfo
+	41, // [41:55] is the sub-list for method output_type
+	27, // [27:41] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_polynav_proto_init() }
@@ -433,13 +2060,14 @@ func file_polynav_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_polynav_proto_rawDesc), len(file_polynav_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      3,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_polynav_proto_goTypes,
 		DependencyIndexes: file_polynav_proto_depIdxs,
+		EnumInfos:         file_polynav_proto_enumTypes,
 		MessageInfos:      file_polynav_proto_msgTypes,
 	}.Build()
 	File_polynav_proto = out.File
@@ -19,8 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GeometryService_Triangulate_FullMethodName = "/polynav.GeometryService/Triangulate"
-	GeometryService_SaveMap_FullMethodName     = "/polynav.GeometryService/SaveMap"
+	GeometryService_Triangulate_FullMethodName       = "/polynav.GeometryService/Triangulate"
+	GeometryService_FindPath_FullMethodName          = "/polynav.GeometryService/FindPath"
+	GeometryService_SaveMap_FullMethodName           = "/polynav.GeometryService/SaveMap"
+	GeometryService_LoadMap_FullMethodName           = "/polynav.GeometryService/LoadMap"
+	GeometryService_ListMaps_FullMethodName          = "/polynav.GeometryService/ListMaps"
+	GeometryService_DeleteMap_FullMethodName         = "/polynav.GeometryService/DeleteMap"
+	GeometryService_ValidateMesh_FullMethodName      = "/polynav.GeometryService/ValidateMesh"
+	GeometryService_TriangulateStream_FullMethodName = "/polynav.GeometryService/TriangulateStream"
+	GeometryService_CreateSession_FullMethodName     = "/polynav.GeometryService/CreateSession"
+	GeometryService_AddObstacle_FullMethodName       = "/polynav.GeometryService/AddObstacle"
+	GeometryService_RemoveObstacle_FullMethodName    = "/polynav.GeometryService/RemoveObstacle"
+	GeometryService_MovePoint_FullMethodName         = "/polynav.GeometryService/MovePoint"
+	GeometryService_GetMesh_FullMethodName           = "/polynav.GeometryService/GetMesh"
+	GeometryService_CloseSession_FullMethodName      = "/polynav.GeometryService/CloseSession"
 )
 
 // GeometryServiceClient is the client API for GeometryService service.
@@ -31,8 +43,35 @@ const (
 type GeometryServiceClient interface {
 	// Perform Delaunay Triangulation on a set of points (obstacles)
 	Triangulate(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*TriangulationResult, error)
-	// Persist map data to the database
+	// Plan a path from start to goal through the constrained triangulation
+	FindPath(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*PathResult, error)
+	// Persist map data to the map store, with its triangulation cached
 	SaveMap(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*SaveMapResponse, error)
+	// Fetch a saved map by ID
+	LoadMap(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*StoredMap, error)
+	// List the saved maps, most recently updated first
+	ListMaps(ctx context.Context, in *ListMapsRequest, opts ...grpc.CallOption) (*MapList, error)
+	// Remove a saved map
+	DeleteMap(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*DeleteMapResponse, error)
+	// Check the mesh built for a map against its invariants (debug mode only)
+	ValidateMesh(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*ValidationResult, error)
+	// Triangulate, reporting progress as the mesh is built; the last event
+	// carries the same result Triangulate returns
+	TriangulateStream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProgressEvent], error)
+	// Open an editing session holding the map's mesh on the server; sessions
+	// expire after a period without calls, and once the server's limit of
+	// open sessions is reached new ones fail with RESOURCE_EXHAUSTED
+	CreateSession(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*SessionInfo, error)
+	// Constrain a new obstacle into a session's mesh
+	AddObstacle(ctx context.Context, in *AddObstacleRequest, opts ...grpc.CallOption) (*ObstacleRef, error)
+	// Remove an obstacle from a session's mesh
+	RemoveObstacle(ctx context.Context, in *ObstacleRef, opts ...grpc.CallOption) (*ObstacleRef, error)
+	// Move one point of an obstacle in a session's mesh
+	MovePoint(ctx context.Context, in *MovePointRequest, opts ...grpc.CallOption) (*ObstacleRef, error)
+	// Classify the session's mesh as Triangulate would for its current map
+	GetMesh(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*TriangulationResult, error)
+	// End a session and release its mesh
+	CloseSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionInfo, error)
 }
 
 type geometryServiceClient struct {
@@ -53,6 +92,16 @@ func (c *geometryServiceClient) Triangulate(ctx context.Context, in *MapData, op
 	return out, nil
 }
 
+func (c *geometryServiceClient) FindPath(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*PathResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PathResult)
+	err := c.cc.Invoke(ctx, GeometryService_FindPath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *geometryServiceClient) SaveMap(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*SaveMapResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SaveMapResponse)
@@ -63,6 +112,125 @@ func (c *geometryServiceClient) SaveMap(ctx context.Context, in *MapData, opts .
 	return out, nil
 }
 
+func (c *geometryServiceClient) LoadMap(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*StoredMap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoredMap)
+	err := c.cc.Invoke(ctx, GeometryService_LoadMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) ListMaps(ctx context.Context, in *ListMapsRequest, opts ...grpc.CallOption) (*MapList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MapList)
+	err := c.cc.Invoke(ctx, GeometryService_ListMaps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) DeleteMap(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*DeleteMapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMapResponse)
+	err := c.cc.Invoke(ctx, GeometryService_DeleteMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) ValidateMesh(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*ValidationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationResult)
+	err := c.cc.Invoke(ctx, GeometryService_ValidateMesh_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) TriangulateStream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProgressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GeometryService_ServiceDesc.Streams[0], GeometryService_TriangulateStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRequest, ProgressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeometryService_TriangulateStreamClient = grpc.ServerStreamingClient[ProgressEvent]
+
+func (c *geometryServiceClient) CreateSession(ctx context.Context, in *MapData, opts ...grpc.CallOption) (*SessionInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionInfo)
+	err := c.cc.Invoke(ctx, GeometryService_CreateSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) AddObstacle(ctx context.Context, in *AddObstacleRequest, opts ...grpc.CallOption) (*ObstacleRef, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ObstacleRef)
+	err := c.cc.Invoke(ctx, GeometryService_AddObstacle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) RemoveObstacle(ctx context.Context, in *ObstacleRef, opts ...grpc.CallOption) (*ObstacleRef, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ObstacleRef)
+	err := c.cc.Invoke(ctx, GeometryService_RemoveObstacle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) MovePoint(ctx context.Context, in *MovePointRequest, opts ...grpc.CallOption) (*ObstacleRef, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ObstacleRef)
+	err := c.cc.Invoke(ctx, GeometryService_MovePoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) GetMesh(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*TriangulationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriangulationResult)
+	err := c.cc.Invoke(ctx, GeometryService_GetMesh_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geometryServiceClient) CloseSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionInfo)
+	err := c.cc.Invoke(ctx, GeometryService_CloseSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GeometryServiceServer is the server API for GeometryService service.
 // All implementations must embed UnimplementedGeometryServiceServer
 // for forward compatibility.
@@ -71,8 +239,35 @@ func (c *geometryServiceClient) SaveMap(ctx context.Context, in *MapData, opts .
 type GeometryServiceServer interface {
 	// Perform Delaunay Triangulation on a set of points (obstacles)
 	Triangulate(context.Context, *MapData) (*TriangulationResult, error)
-	// Persist map data to the database
+	// Plan a path from start to goal through the constrained triangulation
+	FindPath(context.Context, *MapData) (*PathResult, error)
+	// Persist map data to the map store, with its triangulation cached
 	SaveMap(context.Context, *MapData) (*SaveMapResponse, error)
+	// Fetch a saved map by ID
+	LoadMap(context.Context, *MapRequest) (*StoredMap, error)
+	// List the saved maps, most recently updated first
+	ListMaps(context.Context, *ListMapsRequest) (*MapList, error)
+	// Remove a saved map
+	DeleteMap(context.Context, *MapRequest) (*DeleteMapResponse, error)
+	// Check the mesh built for a map against its invariants (debug mode only)
+	ValidateMesh(context.Context, *MapData) (*ValidationResult, error)
+	// Triangulate, reporting progress as the mesh is built; the last event
+	// carries the same result Triangulate returns
+	TriangulateStream(*StreamRequest, grpc.ServerStreamingServer[ProgressEvent]) error
+	// Open an editing session holding the map's mesh on the server; sessions
+	// expire after a period without calls, and once the server's limit of
+	// open sessions is reached new ones fail with RESOURCE_EXHAUSTED
+	CreateSession(context.Context, *MapData) (*SessionInfo, error)
+	// Constrain a new obstacle into a session's mesh
+	AddObstacle(context.Context, *AddObstacleRequest) (*ObstacleRef, error)
+	// Remove an obstacle from a session's mesh
+	RemoveObstacle(context.Context, *ObstacleRef) (*ObstacleRef, error)
+	// Move one point of an obstacle in a session's mesh
+	MovePoint(context.Context, *MovePointRequest) (*ObstacleRef, error)
+	// Classify the session's mesh as Triangulate would for its current map
+	GetMesh(context.Context, *SessionRequest) (*TriangulationResult, error)
+	// End a session and release its mesh
+	CloseSession(context.Context, *SessionRequest) (*SessionInfo, error)
 	mustEmbedUnimplementedGeometryServiceServer()
 }
 
@@ -86,9 +281,45 @@ type UnimplementedGeometryServiceServer struct{}
 func (UnimplementedGeometryServiceServer) Triangulate(context.Context, *MapData) (*TriangulationResult, error) {
 	return nil, status.Error(codes.Unimplemented, "method Triangulate not implemented")
 }
+func (UnimplementedGeometryServiceServer) FindPath(context.Context, *MapData) (*PathResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindPath not implemented")
+}
 func (UnimplementedGeometryServiceServer) SaveMap(context.Context, *MapData) (*SaveMapResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SaveMap not implemented")
 }
+func (UnimplementedGeometryServiceServer) LoadMap(context.Context, *MapRequest) (*StoredMap, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoadMap not implemented")
+}
+func (UnimplementedGeometryServiceServer) ListMaps(context.Context, *ListMapsRequest) (*MapList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMaps not implemented")
+}
+func (UnimplementedGeometryServiceServer) DeleteMap(context.Context, *MapRequest) (*DeleteMapResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteMap not implemented")
+}
+func (UnimplementedGeometryServiceServer) ValidateMesh(context.Context, *MapData) (*ValidationResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidateMesh not implemented")
+}
+func (UnimplementedGeometryServiceServer) TriangulateStream(*StreamRequest, grpc.ServerStreamingServer[ProgressEvent]) error {
+	return status.Error(codes.Unimplemented, "method TriangulateStream not implemented")
+}
+func (UnimplementedGeometryServiceServer) CreateSession(context.Context, *MapData) (*SessionInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSession not implemented")
+}
+func (UnimplementedGeometryServiceServer) AddObstacle(context.Context, *AddObstacleRequest) (*ObstacleRef, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddObstacle not implemented")
+}
+func (UnimplementedGeometryServiceServer) RemoveObstacle(context.Context, *ObstacleRef) (*ObstacleRef, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveObstacle not implemented")
+}
+func (UnimplementedGeometryServiceServer) MovePoint(context.Context, *MovePointRequest) (*ObstacleRef, error) {
+	return nil, status.Error(codes.Unimplemented, "method MovePoint not implemented")
+}
+func (UnimplementedGeometryServiceServer) GetMesh(context.Context, *SessionRequest) (*TriangulationResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMesh not implemented")
+}
+func (UnimplementedGeometryServiceServer) CloseSession(context.Context, *SessionRequest) (*SessionInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloseSession not implemented")
+}
 func (UnimplementedGeometryServiceServer) mustEmbedUnimplementedGeometryServiceServer() {}
 func (UnimplementedGeometryServiceServer) testEmbeddedByValue()                         {}
 
@@ -128,6 +359,24 @@ func _GeometryService_Triangulate_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GeometryService_FindPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).FindPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_FindPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).FindPath(ctx, req.(*MapData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GeometryService_SaveMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MapData)
 	if err := dec(in); err != nil {
@@ -146,6 +395,197 @@ func _GeometryService_SaveMap_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GeometryService_LoadMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).LoadMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_LoadMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).LoadMap(ctx, req.(*MapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_ListMaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).ListMaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_ListMaps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).ListMaps(ctx, req.(*ListMapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_DeleteMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).DeleteMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_DeleteMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).DeleteMap(ctx, req.(*MapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_ValidateMesh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).ValidateMesh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_ValidateMesh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).ValidateMesh(ctx, req.(*MapData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_TriangulateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeometryServiceServer).TriangulateStream(m, &grpc.GenericServerStream[StreamRequest, ProgressEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeometryService_TriangulateStreamServer = grpc.ServerStreamingServer[ProgressEvent]
+
+func _GeometryService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_CreateSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).CreateSession(ctx, req.(*MapData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_AddObstacle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddObstacleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).AddObstacle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_AddObstacle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).AddObstacle(ctx, req.(*AddObstacleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_RemoveObstacle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObstacleRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).RemoveObstacle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_RemoveObstacle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).RemoveObstacle(ctx, req.(*ObstacleRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_MovePoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovePointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).MovePoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_MovePoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).MovePoint(ctx, req.(*MovePointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_GetMesh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).GetMesh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_GetMesh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).GetMesh(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeometryService_CloseSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeometryServiceServer).CloseSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeometryService_CloseSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeometryServiceServer).CloseSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GeometryService_ServiceDesc is the grpc.ServiceDesc for GeometryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,11 +597,61 @@ var GeometryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Triangulate",
 			Handler:    _GeometryService_Triangulate_Handler,
 		},
+		{
+			MethodName: "FindPath",
+			Handler:    _GeometryService_FindPath_Handler,
+		},
 		{
 			MethodName: "SaveMap",
 			Handler:    _GeometryService_SaveMap_Handler,
 		},
+		{
+			MethodName: "LoadMap",
+			Handler:    _GeometryService_LoadMap_Handler,
+		},
+		{
+			MethodName: "ListMaps",
+			Handler:    _GeometryService_ListMaps_Handler,
+		},
+		{
+			MethodName: "DeleteMap",
+			Handler:    _GeometryService_DeleteMap_Handler,
+		},
+		{
+			MethodName: "ValidateMesh",
+			Handler:    _GeometryService_ValidateMesh_Handler,
+		},
+		{
+			MethodName: "CreateSession",
+			Handler:    _GeometryService_CreateSession_Handler,
+		},
+		{
+			MethodName: "AddObstacle",
+			Handler:    _GeometryService_AddObstacle_Handler,
+		},
+		{
+			MethodName: "RemoveObstacle",
+			Handler:    _GeometryService_RemoveObstacle_Handler,
+		},
+		{
+			MethodName: "MovePoint",
+			Handler:    _GeometryService_MovePoint_Handler,
+		},
+		{
+			MethodName: "GetMesh",
+			Handler:    _GeometryService_GetMesh_Handler,
+		},
+		{
+			MethodName: "CloseSession",
+			Handler:    _GeometryService_CloseSession_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TriangulateStream",
+			Handler:       _GeometryService_TriangulateStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "polynav.proto",
 }
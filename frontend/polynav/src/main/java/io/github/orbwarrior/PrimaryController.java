@@ -5,6 +5,7 @@ import com.google.gson.JsonArray;
 import com.google.gson.JsonElement;
 import com.google.gson.JsonParser;
 import com.google.gson.reflect.TypeToken;
+import fyp.generated.Region;
 import fyp.generated.Triangle;
 import io.github.orbwarrior.client.GeometryClient;
 import javafx.fxml.FXML;
@@ -306,6 +307,10 @@ public class PrimaryController {
         gc.setLineWidth(1.0);
 
         for (Triangle t : triangles) {
+            // The server returns the free space around the obstacles too
+            if (t.getRegion() != Region.OBSTACLE) {
+                continue;
+            }
             double[] xPoints = {
                 t.getA().getX() * scale + offsetX, 
                 t.getB().getX() * scale + offsetX, 